@@ -0,0 +1,124 @@
+package gops_handler
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/AvengeMedia/dgop/errdefs"
+	"github.com/AvengeMedia/dgop/gops"
+	"github.com/AvengeMedia/dgop/internal/log"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type SignalInput struct {
+	PID  int32 `path:"pid"`
+	Body struct {
+		Signal string `json:"signal" required:"false" default:"TERM" doc:"Signal name (TERM, SIGKILL, hup) or number"`
+	}
+}
+
+type SignalResponse struct {
+	Body struct {
+		PID    int32  `json:"pid"`
+		Signal string `json:"signal"`
+	}
+}
+
+type ReniceInput struct {
+	PID  int32 `path:"pid"`
+	Body struct {
+		Nice int `json:"nice" minimum:"-20" maximum:"19"`
+	}
+}
+
+type ReniceResponse struct {
+	Body struct {
+		PID  int32 `json:"pid"`
+		Nice int   `json:"nice"`
+	}
+}
+
+// POST /processes/{pid}/signal
+func (self *HandlerGroup) Signal(ctx context.Context, input *SignalInput) (*SignalResponse, error) {
+	sig, err := gops.ParseSignal(input.Body.Signal)
+	if err != nil {
+		return nil, processError(err)
+	}
+
+	if err := self.srv.Gops.SignalProcess(input.PID, sig); err != nil {
+		return nil, processError(err)
+	}
+	log.Infof("Sent %s to pid %d", gops.SignalName(sig), input.PID)
+
+	resp := &SignalResponse{}
+	resp.Body.PID = input.PID
+	resp.Body.Signal = gops.SignalName(sig)
+	return resp, nil
+}
+
+// POST /processes/{pid}/renice
+func (self *HandlerGroup) Renice(ctx context.Context, input *ReniceInput) (*ReniceResponse, error) {
+	if err := self.srv.Gops.ReniceProcess(input.PID, input.Body.Nice); err != nil {
+		return nil, processError(err)
+	}
+	log.Infof("Reniced pid %d to %d", input.PID, input.Body.Nice)
+
+	resp := &ReniceResponse{}
+	resp.Body.PID = input.PID
+	resp.Body.Nice = input.Body.Nice
+	return resp, nil
+}
+
+// processActionsEnabled keeps the signal and renice endpoints off an API
+// without keys unless the config asks for them
+func (self *HandlerGroup) processActionsEnabled() bool {
+	return self.srv.Cfg != nil && self.srv.Cfg.Server.ProcessActionsEnabled()
+}
+
+// guardAction refuses process actions a web page could trigger. A form POST
+// from another site needs no preflight, so only JSON bodies are accepted and
+// the Origin has to be one of the CORS origins.
+func (self *HandlerGroup) guardAction(api huma.API) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if !self.allowedOrigin(ctx.Header("Origin")) {
+			huma.WriteErr(api, ctx, http.StatusForbidden, "Origin not allowed")
+			return
+		}
+		if mediaType, _, _ := mime.ParseMediaType(ctx.Header("Content-Type")); mediaType != "application/json" {
+			huma.WriteErr(api, ctx, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+			return
+		}
+		next(ctx)
+	}
+}
+
+// processError maps errdefs errors from the per process operations to
+// HTTP statuses
+func processError(err error) error {
+	var customErr *errdefs.CustomError
+	if !errors.As(err, &customErr) {
+		log.Error("Error accessing process", "err", err)
+		return huma.Error500InternalServerError("Unable to access process")
+	}
+
+	switch customErr.Type {
+	case errdefs.ErrTypePermissionDenied:
+		return huma.Error403Forbidden(customErr.Message)
+	case errdefs.ErrTypeNotFound:
+		return huma.Error404NotFound(customErr.Message)
+	default:
+		return huma.Error400BadRequest(customErr.Message)
+	}
+}
+
+// invalidInputError turns an errdefs invalid input error into a 400 and
+// returns nil for anything else, which the caller reports itself
+func invalidInputError(err error) error {
+	var customErr *errdefs.CustomError
+	if errors.As(err, &customErr) && customErr.Type == errdefs.ErrTypeInvalidInput {
+		return huma.Error400BadRequest(customErr.Message)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package gops_handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/AvengeMedia/dgop/api/server"
+	"github.com/AvengeMedia/dgop/config"
+	"github.com/AvengeMedia/dgop/gops"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRouter(t *testing.T, cfg config.Config) http.Handler {
+	r := chi.NewRouter()
+	api := humachi.New(r, huma.DefaultConfig("dgop", "test"))
+	RegisterHandlers(&server.Server{Cfg: &cfg, Gops: gops.NewGopsUtil()}, huma.NewGroup(api, "/gops"))
+	return r
+}
+
+// startSleep runs a process the test user may signal
+func startSleep(t *testing.T) *exec.Cmd {
+	cmd := exec.Command("sleep", "60")
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+	return cmd
+}
+
+func postSignal(handler http.Handler, pid int, contentType, origin, body string) int {
+	req := httptest.NewRequest(http.MethodPost, "/gops/processes/"+strconv.Itoa(pid)+"/signal", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestProcessActionsNeedKeysOrOptIn(t *testing.T) {
+	pid := startSleep(t).Process.Pid
+
+	cfg := config.Default()
+	assert.Equal(t, http.StatusNotFound, postSignal(testRouter(t, cfg), pid, "application/json", "", `{"signal":"CONT"}`))
+
+	cfg.Server.APIKeys = []string{"key"}
+	assert.Equal(t, http.StatusOK, postSignal(testRouter(t, cfg), pid, "application/json", "", `{"signal":"CONT"}`))
+
+	cfg = config.Default()
+	cfg.Server.AllowProcessActions = true
+	assert.Equal(t, http.StatusOK, postSignal(testRouter(t, cfg), pid, "application/json", "", `{"signal":"CONT"}`))
+}
+
+func TestProcessActionsRefuseCrossSiteRequests(t *testing.T) {
+	cmd := startSleep(t)
+	pid := cmd.Process.Pid
+
+	cfg := config.Default()
+	cfg.Server.AllowProcessActions = true
+	cfg.Server.CORS.AllowedOrigins = []string{"https://dash.example.com"}
+	handler := testRouter(t, cfg)
+
+	tests := map[string]struct {
+		contentType string
+		origin      string
+		body        string
+		status      int
+	}{
+		"form post":         {"application/x-www-form-urlencoded", "", "signal=KILL", http.StatusUnsupportedMediaType},
+		"no content type":   {"", "", "{}", http.StatusUnsupportedMediaType},
+		"text/plain":        {"text/plain", "", `{"signal":"KILL"}`, http.StatusUnsupportedMediaType},
+		"foreign origin":    {"application/json", "https://evil.example", `{"signal":"KILL"}`, http.StatusForbidden},
+		"foreign form post": {"application/x-www-form-urlencoded", "https://evil.example", "signal=KILL", http.StatusForbidden},
+	}
+	for name, test := range tests {
+		assert.Equal(t, test.status, postSignal(handler, pid, test.contentType, test.origin, test.body), name)
+	}
+	// None of them reached the process
+	assert.Nil(t, cmd.ProcessState)
+	assert.Equal(t, http.StatusOK, postSignal(handler, pid, "application/json", "", `{"signal":"CONT"}`))
+
+	assert.Equal(t, http.StatusOK, postSignal(handler, pid, "application/json; charset=utf-8", "https://dash.example.com", `{"signal":"TERM"}`))
+	err := cmd.Wait()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, "signal: terminated", exitErr.Error())
+}
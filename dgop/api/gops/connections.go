@@ -0,0 +1,36 @@
+package gops_handler
+
+import (
+	"context"
+
+	"github.com/AvengeMedia/dgop/internal/log"
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ConnectionsInput struct {
+	Protocols []string `query:"protocols" example:"tcp,udp" doc:"Any of tcp, tcp6, udp, udp6 and unix, all when empty"`
+	Listening bool     `query:"listening" default:"false" doc:"Only listening sockets"`
+}
+
+type ConnectionsResponse struct {
+	Body struct {
+		Data []*models.ConnectionInfo `json:"data"`
+	}
+}
+
+// GET /connections
+func (self *HandlerGroup) Connections(ctx context.Context, input *ConnectionsInput) (*ConnectionsResponse, error) {
+	conns, err := self.srv.Gops.GetConnections(input.Protocols, input.Listening)
+	if err != nil {
+		if inputErr := invalidInputError(err); inputErr != nil {
+			return nil, inputErr
+		}
+		log.Error("Error getting connections")
+		return nil, huma.Error500InternalServerError("Unable to retrieve connections")
+	}
+
+	resp := &ConnectionsResponse{}
+	resp.Body.Data = conns
+	return resp, nil
+}
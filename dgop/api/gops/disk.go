@@ -3,12 +3,22 @@ package gops_handler
 import (
 	"context"
 
-	"github.com/AvengeMedia/dgop/api/server"
+	"github.com/AvengeMedia/dgop/gops"
 	"github.com/AvengeMedia/dgop/internal/log"
 	"github.com/AvengeMedia/dgop/models"
 	"github.com/danielgtaylor/huma/v2"
 )
 
+// DiskFilterInput overrides the configured disk filter, see gops.DeviceFilter.Override
+type DiskFilterInput struct {
+	DiskInclude []string `query:"disk_include" example:"nvme*,sd?" doc:"Disk name globs to keep"`
+	DiskExclude []string `query:"disk_exclude" example:"dm-*,sr*" doc:"Disk name globs to leave out"`
+}
+
+func (input *DiskFilterInput) diskFilter() gops.DeviceFilter {
+	return gops.DeviceFilter{Include: input.DiskInclude, Exclude: input.DiskExclude}
+}
+
 type DiskResponse struct {
 	Body struct {
 		Data []*models.DiskInfo `json:"data"`
@@ -16,10 +26,13 @@ type DiskResponse struct {
 }
 
 // GET /disk
-func (self *HandlerGroup) Disk(ctx context.Context, _ *server.EmptyInput) (*DiskResponse, error) {
+func (self *HandlerGroup) Disk(ctx context.Context, input *DiskFilterInput) (*DiskResponse, error) {
 
-	diskInfo, err := self.srv.Gops.GetDiskInfo()
+	diskInfo, err := self.srv.Gops.GetDiskInfoFiltered(input.diskFilter())
 	if err != nil {
+		if inputErr := invalidInputError(err); inputErr != nil {
+			return nil, inputErr
+		}
 		log.Error("Error getting Disk info")
 		return nil, huma.Error500InternalServerError("Unable to retrieve Disk info")
 	}
@@ -30,15 +43,19 @@ func (self *HandlerGroup) Disk(ctx context.Context, _ *server.EmptyInput) (*Disk
 }
 
 // GET /disk/mounts
+type DiskMountsInput struct {
+	SkipFSTypes []string `query:"skip_fstypes" example:"tmpfs,devtmpfs,squashfs" doc:"Filesystem types to leave out, tmpfs,devtmpfs when empty and none to keep all"`
+}
+
 type DiskMountsResponse struct {
 	Body struct {
 		Data []*models.DiskMountInfo `json:"data"`
 	}
 }
 
-func (self *HandlerGroup) DiskMounts(ctx context.Context, _ *server.EmptyInput) (*DiskMountsResponse, error) {
+func (self *HandlerGroup) DiskMounts(ctx context.Context, input *DiskMountsInput) (*DiskMountsResponse, error) {
 
-	diskMountsInfo, err := self.srv.Gops.GetDiskMounts()
+	diskMountsInfo, err := self.srv.Gops.GetDiskMountsFiltered(input.SkipFSTypes)
 	if err != nil {
 		log.Error("Error getting Disk Mounts info")
 		return nil, huma.Error500InternalServerError("Unable to retrieve Disk Mounts info")
@@ -48,3 +65,27 @@ func (self *HandlerGroup) DiskMounts(ctx context.Context, _ *server.EmptyInput)
 	resp.Body.Data = diskMountsInfo
 	return resp, nil
 }
+
+// GET /blockdevices
+type BlockDevicesInput struct {
+	SMART bool `query:"smart" default:"false" doc:"Include SMART health, needs smartctl and usually root"`
+}
+
+type BlockDevicesResponse struct {
+	Body struct {
+		Data []*models.BlockDevice `json:"data"`
+	}
+}
+
+func (self *HandlerGroup) BlockDevices(ctx context.Context, input *BlockDevicesInput) (*BlockDevicesResponse, error) {
+
+	devices, err := self.srv.Gops.GetBlockDevices(input.SMART)
+	if err != nil {
+		log.Error("Error getting Block Devices")
+		return nil, huma.Error500InternalServerError("Unable to retrieve Block Devices")
+	}
+
+	resp := &BlockDevicesResponse{}
+	resp.Body.Data = devices
+	return resp, nil
+}
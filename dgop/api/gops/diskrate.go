@@ -10,6 +10,7 @@ import (
 
 type DiskRateInput struct {
 	Cursor string `query:"cursor" doc:"Base64 cursor for rate calculation"`
+	DiskFilterInput
 }
 
 type DiskRateResponse struct {
@@ -18,8 +19,11 @@ type DiskRateResponse struct {
 
 // GET /disk-rate
 func (self *HandlerGroup) DiskRate(ctx context.Context, input *DiskRateInput) (*DiskRateResponse, error) {
-	diskRateInfo, err := self.srv.Gops.GetDiskRates(input.Cursor)
+	diskRateInfo, err := self.srv.Gops.GetDiskRatesFiltered(input.Cursor, input.diskFilter())
 	if err != nil {
+		if inputErr := invalidInputError(err); inputErr != nil {
+			return nil, inputErr
+		}
 		log.Error("Error getting disk rates")
 		return nil, huma.Error500InternalServerError("Unable to retrieve disk rates")
 	}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"github.com/AvengeMedia/dgop/api/server"
+	"github.com/AvengeMedia/dgop/models"
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/sse"
 )
 
 type HandlerGroup struct {
@@ -64,6 +66,54 @@ func RegisterHandlers(server *server.Server, grp *huma.Group) {
 		handlers.Network,
 	)
 
+	huma.Register(
+		grp,
+		huma.Operation{
+			OperationID: "power",
+			Summary:     "Get Power Info",
+			Description: "Get batteries and power adapters, pass the returned cursor back for battery time estimates",
+			Path:        "/power",
+			Method:      http.MethodGet,
+		},
+		handlers.Power,
+	)
+
+	huma.Register(
+		grp,
+		huma.Operation{
+			OperationID: "sensors",
+			Summary:     "Get Sensors",
+			Description: "Get temperature, fan, voltage, power and current readings of every hwmon chip with their thresholds and alarms",
+			Path:        "/sensors",
+			Method:      http.MethodGet,
+		},
+		handlers.Sensors,
+	)
+
+	huma.Register(
+		grp,
+		huma.Operation{
+			OperationID: "temperatures",
+			Summary:     "Get Temperatures",
+			Description: "Get temperature sensors grouped by chip, with their labels, high and critical thresholds",
+			Path:        "/temperatures",
+			Method:      http.MethodGet,
+		},
+		handlers.Temperatures,
+	)
+
+	huma.Register(
+		grp,
+		huma.Operation{
+			OperationID: "connections",
+			Summary:     "Get Connections",
+			Description: "Get tcp, udp and unix sockets with their owning processes",
+			Path:        "/connections",
+			Method:      http.MethodGet,
+		},
+		handlers.Connections,
+	)
+
 	huma.Register(
 		grp,
 		huma.Operation{
@@ -112,6 +162,46 @@ func RegisterHandlers(server *server.Server, grp *huma.Group) {
 		handlers.Processes,
 	)
 
+	huma.Register(
+		grp,
+		huma.Operation{
+			OperationID: "process-detail",
+			Summary:     "Get Process Detail",
+			Description: "Get state, limits, file descriptors, cgroup, namespaces and I/O counters of a single process",
+			Path:        "/processes/{pid}",
+			Method:      http.MethodGet,
+		},
+		handlers.ProcessDetail,
+	)
+
+	if handlers.processActionsEnabled() {
+		huma.Register(
+			grp,
+			huma.Operation{
+				OperationID: "process-signal",
+				Summary:     "Signal Process",
+				Description: "Send a signal to a process. PID 1, the server itself and other users' processes are refused.",
+				Path:        "/processes/{pid}/signal",
+				Method:      http.MethodPost,
+				Middlewares: huma.Middlewares{handlers.guardAction(grp)},
+			},
+			handlers.Signal,
+		)
+
+		huma.Register(
+			grp,
+			huma.Operation{
+				OperationID: "process-renice",
+				Summary:     "Renice Process",
+				Description: "Set the nice value of a process. PID 1, the server itself and other users' processes are refused.",
+				Path:        "/processes/{pid}/renice",
+				Method:      http.MethodPost,
+				Middlewares: huma.Middlewares{handlers.guardAction(grp)},
+			},
+			handlers.Renice,
+		)
+	}
+
 	huma.Register(
 		grp,
 		huma.Operation{
@@ -136,6 +226,18 @@ func RegisterHandlers(server *server.Server, grp *huma.Group) {
 		handlers.DiskMounts,
 	)
 
+	huma.Register(
+		grp,
+		huma.Operation{
+			OperationID: "blockdevices",
+			Summary:     "Get Block Devices",
+			Description: "Get block devices with partitions, holders and device-mapper stack, optionally with SMART health through smartctl",
+			Path:        "/blockdevices",
+			Method:      http.MethodGet,
+		},
+		handlers.BlockDevices,
+	)
+
 	huma.Register(
 		grp,
 		huma.Operation{
@@ -172,6 +274,18 @@ func RegisterHandlers(server *server.Server, grp *huma.Group) {
 		handlers.GPUTemp,
 	)
 
+	huma.Register(
+		grp,
+		huma.Operation{
+			OperationID: "gpu-usage",
+			Summary:     "Get GPU Usage",
+			Description: "Get utilization, VRAM, clocks and power draw of each GPU by PCI address, nvidia cards through nvidia-smi",
+			Path:        "/gpu/usage",
+			Method:      http.MethodGet,
+		},
+		handlers.GPUUsage,
+	)
+
 	huma.Register(
 		grp,
 		huma.Operation{
@@ -184,6 +298,34 @@ func RegisterHandlers(server *server.Server, grp *huma.Group) {
 		handlers.Meta,
 	)
 
+	sse.Register(
+		grp,
+		huma.Operation{
+			OperationID: "stream",
+			Summary:     "Stream Dynamic Metrics",
+			Description: "Stream system metrics for specified modules as Server-Sent Events, keeping rate cursors server-side per connection",
+			Path:        "/stream",
+			Method:      http.MethodGet,
+		},
+		map[string]any{
+			"meta":  models.MetaInfo{},
+			"error": StreamError{},
+		},
+		handlers.Stream,
+	)
+
+	huma.Register(
+		grp,
+		huma.Operation{
+			OperationID: "stream-ws",
+			Summary:     "Stream Dynamic Metrics (WebSocket)",
+			Description: "Stream system metrics for specified modules over a WebSocket, keeping rate cursors server-side per connection",
+			Path:        "/stream/ws",
+			Method:      http.MethodGet,
+		},
+		handlers.StreamWebSocket,
+	)
+
 	huma.Register(
 		grp,
 		huma.Operation{
@@ -24,6 +24,10 @@ type GPUTempResponse struct {
 	Body *models.GPUTempInfo
 }
 
+type GPUUsageResponse struct {
+	Body *models.GPUUsageInfo
+}
+
 // GET /hardware
 func (self *HandlerGroup) SystemHardware(ctx context.Context, input *struct{}) (*SystemHardwareResponse, error) {
 	systemInfo, err := self.srv.Gops.GetSystemHardware()
@@ -56,3 +60,14 @@ func (self *HandlerGroup) GPUTemp(ctx context.Context, input *GPUTempInput) (*GP
 
 	return &GPUTempResponse{Body: gpuTempInfo}, nil
 }
+
+// GET /gpu/usage
+func (self *HandlerGroup) GPUUsage(ctx context.Context, input *struct{}) (*GPUUsageResponse, error) {
+	gpuUsage, err := self.srv.Gops.GetGPUUsage()
+	if err != nil {
+		log.Error("Error getting GPU usage")
+		return nil, huma.Error500InternalServerError("Unable to retrieve GPU usage")
+	}
+
+	return &GPUUsageResponse{Body: gpuUsage}, nil
+}
@@ -22,6 +22,13 @@ type MetaInput struct {
 	ProcCursor     string   `query:"proc_cursor" doc:"Process cursor from previous request"`
 	NetRateCursor  string   `query:"net_rate_cursor" doc:"Network rate cursor from previous request"`
 	DiskRateCursor string   `query:"disk_rate_cursor" doc:"Disk rate cursor from previous request"`
+	PowerCursor    string   `query:"power_cursor" doc:"Power cursor from previous request, for battery time estimates"`
+	Listening      bool     `query:"listening" default:"false" doc:"Only listening sockets (when connections module is requested)"`
+	SkipFSTypes    []string `query:"skip_fstypes" example:"tmpfs,devtmpfs,squashfs" doc:"Filesystem types left out of diskmounts, tmpfs,devtmpfs when empty and none to keep all"`
+	SMART          bool     `query:"smart" default:"false" doc:"Include SMART health in blockdevices, needs smartctl"`
+	ProcessFilterInput
+	NetFilterInput
+	DiskFilterInput
 }
 
 type MetaResponse struct {
@@ -34,19 +41,17 @@ type ModulesResponse struct {
 
 // GET /meta
 func (self *HandlerGroup) Meta(ctx context.Context, input *MetaInput) (*MetaResponse, error) {
-	// Parse modules if it's a single comma-separated string
-	var modules []string
-	if len(input.Modules) == 1 && strings.Contains(input.Modules[0], ",") {
-		modules = strings.Split(input.Modules[0], ",")
-		// Trim whitespace
-		for i, module := range modules {
-			modules[i] = strings.TrimSpace(module)
-		}
-	} else {
-		modules = input.Modules
+	metaInfo, err := self.srv.Gops.GetMeta(parseModules(input.Modules), input.params())
+	if err != nil {
+		log.Error("Error getting meta info")
+		return nil, huma.Error400BadRequest(err.Error())
 	}
 
-	params := gops.MetaParams{
+	return &MetaResponse{Body: metaInfo}, nil
+}
+
+func (input *MetaInput) params() gops.MetaParams {
+	return gops.MetaParams{
 		SortBy:         input.SortBy,
 		ProcLimit:      input.Limit,
 		EnableCPU:      !input.DisableProcCPU,
@@ -55,15 +60,27 @@ func (self *HandlerGroup) Meta(ctx context.Context, input *MetaInput) (*MetaResp
 		ProcCursor:     input.ProcCursor,
 		NetRateCursor:  input.NetRateCursor,
 		DiskRateCursor: input.DiskRateCursor,
+		ProcFilter:     input.filter(),
+		ListeningOnly:  input.Listening,
+		PowerCursor:    input.PowerCursor,
+		SkipFSTypes:    input.SkipFSTypes,
+		SMART:          input.SMART,
+		NetFilter:      input.netFilter(),
+		DiskFilter:     input.diskFilter(),
 	}
+}
 
-	metaInfo, err := self.srv.Gops.GetMeta(modules, params)
-	if err != nil {
-		log.Error("Error getting meta info")
-		return nil, huma.Error400BadRequest(err.Error())
+// Parse modules if it's a single comma-separated string
+func parseModules(input []string) []string {
+	if len(input) == 1 && strings.Contains(input[0], ",") {
+		modules := strings.Split(input[0], ",")
+		// Trim whitespace
+		for i, module := range modules {
+			modules[i] = strings.TrimSpace(module)
+		}
+		return modules
 	}
-
-	return &MetaResponse{Body: metaInfo}, nil
+	return input
 }
 
 // GET /modules
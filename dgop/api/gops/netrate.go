@@ -10,6 +10,7 @@ import (
 
 type NetRateInput struct {
 	Cursor string `query:"cursor" doc:"Base64 cursor for rate calculation"`
+	NetFilterInput
 }
 
 type NetRateResponse struct {
@@ -18,8 +19,11 @@ type NetRateResponse struct {
 
 // GET /net-rate
 func (self *HandlerGroup) NetRate(ctx context.Context, input *NetRateInput) (*NetRateResponse, error) {
-	netRateInfo, err := self.srv.Gops.GetNetworkRates(input.Cursor)
+	netRateInfo, err := self.srv.Gops.GetNetworkRatesFiltered(input.Cursor, input.netFilter())
 	if err != nil {
+		if inputErr := invalidInputError(err); inputErr != nil {
+			return nil, inputErr
+		}
 		log.Error("Error getting network rates")
 		return nil, huma.Error500InternalServerError("Unable to retrieve network rates")
 	}
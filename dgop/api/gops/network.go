@@ -3,12 +3,22 @@ package gops_handler
 import (
 	"context"
 
-	"github.com/AvengeMedia/dgop/api/server"
+	"github.com/AvengeMedia/dgop/gops"
 	"github.com/AvengeMedia/dgop/internal/log"
 	"github.com/AvengeMedia/dgop/models"
 	"github.com/danielgtaylor/huma/v2"
 )
 
+// NetFilterInput overrides the configured interface filter, see gops.DeviceFilter.Override
+type NetFilterInput struct {
+	NetInclude []string `query:"net_include" example:"wl*,en*,wg*" doc:"Interface name globs to keep"`
+	NetExclude []string `query:"net_exclude" example:"lo,veth*,docker*" doc:"Interface name globs to leave out"`
+}
+
+func (input *NetFilterInput) netFilter() gops.DeviceFilter {
+	return gops.DeviceFilter{Include: input.NetInclude, Exclude: input.NetExclude}
+}
+
 type NetworkResponse struct {
 	Body struct {
 		Data []*models.NetworkInfo `json:"data"`
@@ -16,10 +26,13 @@ type NetworkResponse struct {
 }
 
 // GET /network
-func (self *HandlerGroup) Network(ctx context.Context, _ *server.EmptyInput) (*NetworkResponse, error) {
+func (self *HandlerGroup) Network(ctx context.Context, input *NetFilterInput) (*NetworkResponse, error) {
 
-	networkInfo, err := self.srv.Gops.GetNetworkInfo()
+	networkInfo, err := self.srv.Gops.GetNetworkInfoFiltered(input.netFilter())
 	if err != nil {
+		if inputErr := invalidInputError(err); inputErr != nil {
+			return nil, inputErr
+		}
 		log.Error("Error getting Network info")
 		return nil, huma.Error500InternalServerError("Unable to retrieve Network info")
 	}
@@ -0,0 +1,30 @@
+package gops_handler
+
+import (
+	"context"
+
+	"github.com/AvengeMedia/dgop/internal/log"
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type PowerInput struct {
+	Cursor string `query:"cursor" doc:"Base64 cursor for battery time estimates"`
+}
+
+type PowerResponse struct {
+	Body *models.PowerInfo
+}
+
+// GET /power
+func (self *HandlerGroup) Power(ctx context.Context, input *PowerInput) (*PowerResponse, error) {
+	powerInfo, err := self.srv.Gops.GetPowerInfo(input.Cursor)
+	if err != nil {
+		log.Error("Error getting power info")
+		return nil, huma.Error500InternalServerError("Unable to retrieve power info")
+	}
+
+	resp := &PowerResponse{}
+	resp.Body = powerInfo
+	return resp, nil
+}
@@ -14,12 +14,40 @@ type ProcessInput struct {
 	Limit          int             `query:"limit"`
 	DisableProcCPU bool            `query:"disable_proc_cpu" default:"false"`
 	Cursor         string          `query:"cursor" required:"false"`
+	Tree           bool            `query:"tree" default:"false" doc:"Nest processes under their parents in tree instead of data"`
+	ProcessFilterInput
+}
+
+// ProcessFilterInput is applied before limit, so limit counts matching processes only
+type ProcessFilterInput struct {
+	Users             []string `query:"user" doc:"Only processes owned by these users"`
+	Command           string   `query:"command" doc:"Regular expression matched against the process name"`
+	Cmdline           string   `query:"cmdline" doc:"Regular expression matched against the full command line"`
+	PIDs              []int32  `query:"pids" doc:"Only these PIDs"`
+	MinCPU            float64  `query:"min_cpu" minimum:"0" doc:"Minimum CPU percent"`
+	MinMemory         float32  `query:"min_memory" minimum:"0" doc:"Minimum memory percent"`
+	HideKernelThreads bool     `query:"hide_kernel_threads" default:"false"`
+	OnlyMine          bool     `query:"only_mine" default:"false" doc:"Only processes owned by the user running the server"`
+}
+
+func (input *ProcessFilterInput) filter() gops.ProcessFilter {
+	return gops.ProcessFilter{
+		Usernames:         input.Users,
+		Command:           input.Command,
+		Cmdline:           input.Cmdline,
+		PIDs:              input.PIDs,
+		MinCPU:            input.MinCPU,
+		MinMemory:         input.MinMemory,
+		HideKernelThreads: input.HideKernelThreads,
+		OnlyMine:          input.OnlyMine,
+	}
 }
 
 type ProcessResponse struct {
 	Body struct {
-		Data   []*models.ProcessInfo `json:"data"`
-		Cursor string                `json:"cursor,omitempty"`
+		Data   []*models.ProcessInfo     `json:"data"`
+		Tree   []*models.ProcessTreeNode `json:"tree,omitempty"`
+		Cursor string                    `json:"cursor,omitempty"`
 	}
 }
 
@@ -27,14 +55,57 @@ type ProcessResponse struct {
 func (self *HandlerGroup) Processes(ctx context.Context, input *ProcessInput) (*ProcessResponse, error) {
 	enableCPU := !input.DisableProcCPU
 
-	result, err := self.srv.Gops.GetProcessesWithCursor(input.SortBy, input.Limit, enableCPU, input.Cursor)
+	if input.Tree {
+		result, err := self.srv.Gops.GetProcessTree(input.SortBy, input.Limit, enableCPU, input.Cursor, input.filter())
+		if err != nil {
+			if inputErr := invalidInputError(err); inputErr != nil {
+				return nil, inputErr
+			}
+			log.Error("Error getting process tree")
+			return nil, huma.Error500InternalServerError("Unable to retrieve process tree")
+		}
+
+		// data stays an empty list for clients that don't know about tree
+		resp := &ProcessResponse{}
+		resp.Body.Data = []*models.ProcessInfo{}
+		resp.Body.Tree = result.Processes
+		resp.Body.Cursor = result.Cursor
+		return resp, nil
+	}
+
+	result, err := self.srv.Gops.GetFilteredProcesses(input.SortBy, input.Limit, enableCPU, input.Cursor, input.filter())
 	if err != nil {
+		if inputErr := invalidInputError(err); inputErr != nil {
+			return nil, inputErr
+		}
 		log.Error("Error getting process info")
 		return nil, huma.Error500InternalServerError("Unable to retrieve process info")
 	}
 
 	resp := &ProcessResponse{}
 	resp.Body.Data = result.Processes
+	if resp.Body.Data == nil {
+		resp.Body.Data = []*models.ProcessInfo{}
+	}
 	resp.Body.Cursor = result.Cursor
 	return resp, nil
 }
+
+type ProcessDetailInput struct {
+	PID int32 `path:"pid"`
+	Env bool  `query:"env" default:"false" doc:"Include the environment, which may contain secrets"`
+}
+
+type ProcessDetailResponse struct {
+	Body *models.ProcessDetail
+}
+
+// GET /processes/{pid}
+func (self *HandlerGroup) ProcessDetail(ctx context.Context, input *ProcessDetailInput) (*ProcessDetailResponse, error) {
+	detail, err := self.srv.Gops.GetProcessDetail(input.PID, input.Env)
+	if err != nil {
+		return nil, processError(err)
+	}
+
+	return &ProcessDetailResponse{Body: detail}, nil
+}
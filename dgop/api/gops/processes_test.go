@@ -0,0 +1,29 @@
+package gops_handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AvengeMedia/dgop/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessesAlwaysSendsData(t *testing.T) {
+	router := testRouter(t, config.Default())
+
+	for _, url := range []string{
+		"/gops/processes?limit=2&command=zzzznomatch&disable_proc_cpu=true",
+		"/gops/processes?tree=true&command=zzzznomatch&disable_proc_cpu=true",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		require.Equal(t, http.StatusOK, w.Code, url)
+
+		var body map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), url)
+		assert.JSONEq(t, "[]", string(body["data"]), url)
+	}
+}
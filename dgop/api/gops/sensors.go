@@ -0,0 +1,48 @@
+package gops_handler
+
+import (
+	"context"
+
+	"github.com/AvengeMedia/dgop/api/server"
+	"github.com/AvengeMedia/dgop/internal/log"
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type SensorsResponse struct {
+	Body struct {
+		Data []*models.SensorChip `json:"data"`
+	}
+}
+
+// GET /sensors
+func (self *HandlerGroup) Sensors(ctx context.Context, _ *server.EmptyInput) (*SensorsResponse, error) {
+	chips, err := self.srv.Gops.GetSensors()
+	if err != nil {
+		log.Error("Error getting sensors")
+		return nil, huma.Error500InternalServerError("Unable to retrieve sensors")
+	}
+
+	resp := &SensorsResponse{}
+	resp.Body.Data = chips
+	return resp, nil
+}
+
+type TemperaturesResponse struct {
+	Body struct {
+		Data []*models.TemperatureChip `json:"data"`
+	}
+}
+
+// GET /temperatures
+func (self *HandlerGroup) Temperatures(ctx context.Context, _ *server.EmptyInput) (*TemperaturesResponse, error) {
+	chips, err := self.srv.Gops.GetTemperatures()
+	if err != nil {
+		log.Error("Error getting temperatures")
+		return nil, huma.Error500InternalServerError("Unable to retrieve temperatures")
+	}
+
+	resp := &TemperaturesResponse{}
+	resp.Body.Data = chips
+	return resp, nil
+}
@@ -0,0 +1,119 @@
+package gops_handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/AvengeMedia/dgop/internal/log"
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+	"github.com/danielgtaylor/huma/v2/sse"
+	"golang.org/x/net/websocket"
+)
+
+type StreamInput struct {
+	MetaInput
+	Interval int `query:"interval" default:"1000" minimum:"250" doc:"Milliseconds between frames"`
+}
+
+type StreamError struct {
+	Message string `json:"message"`
+}
+
+// GET /stream
+func (self *HandlerGroup) Stream(ctx context.Context, input *StreamInput, send sse.Sender) {
+	err := self.runStream(ctx, input, func(meta *models.MetaInfo) error {
+		return send.Data(meta)
+	})
+	if err != nil && ctx.Err() == nil {
+		send.Data(&StreamError{Message: err.Error()})
+	}
+}
+
+// GET /stream/ws
+func (self *HandlerGroup) StreamWebSocket(ctx context.Context, input *StreamInput) (*huma.StreamResponse, error) {
+	return &huma.StreamResponse{
+		Body: func(hctx huma.Context) {
+			r, w := humachi.Unwrap(hctx)
+
+			server := websocket.Server{
+				Handshake: self.checkOrigin,
+				Handler: func(conn *websocket.Conn) {
+					streamCtx, cancel := context.WithCancel(r.Context())
+					defer cancel()
+
+					// Nothing is expected from the client, reading only detects the close
+					go func() {
+						var discard string
+						for websocket.Message.Receive(conn, &discard) == nil {
+						}
+						cancel()
+					}()
+
+					err := self.runStream(streamCtx, input, func(meta *models.MetaInfo) error {
+						return websocket.JSON.Send(conn, meta)
+					})
+					if err != nil && streamCtx.Err() == nil {
+						websocket.JSON.Send(conn, &StreamError{Message: err.Error()})
+					}
+				},
+			}
+			server.ServeHTTP(w, r)
+		},
+	}, nil
+}
+
+// checkOrigin lets through clients without an Origin header, such as QML,
+// and pages from the configured CORS origins. Browsers don't apply CORS to
+// WebSockets, so without it any open web page could read the stream.
+func (self *HandlerGroup) checkOrigin(config *websocket.Config, r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if !self.allowedOrigin(origin) {
+		return fmt.Errorf("origin %s is not allowed", origin)
+	}
+	return nil
+}
+
+// allowedOrigin accepts an empty origin and the configured CORS origins
+func (self *HandlerGroup) allowedOrigin(origin string) bool {
+	if origin == "" {
+		return true
+	}
+
+	var allowed []string
+	if self.srv.Cfg != nil {
+		allowed = self.srv.Cfg.Server.CORS.AllowedOrigins
+	}
+	return slices.Contains(allowed, "*") || slices.Contains(allowed, origin)
+}
+
+// runStream sends a meta frame immediately and then once per interval until
+// ctx is done or send fails. Cursors are kept per connection by the MetaStream.
+func (self *HandlerGroup) runStream(ctx context.Context, input *StreamInput, send func(*models.MetaInfo) error) error {
+	stream := self.srv.Gops.NewMetaStream(parseModules(input.Modules), input.params())
+
+	ticker := time.NewTicker(time.Duration(input.Interval) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		meta, err := stream.Next()
+		if err != nil {
+			log.Error("Error getting meta info for stream")
+			return err
+		}
+
+		if err := send(meta); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
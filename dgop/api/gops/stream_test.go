@@ -0,0 +1,78 @@
+package gops_handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AvengeMedia/dgop/api/server"
+	"github.com/AvengeMedia/dgop/config"
+	"github.com/AvengeMedia/dgop/gops"
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+func streamServer(t *testing.T, allowedOrigins ...string) string {
+	cfg := config.Default()
+	cfg.Server.CORS.AllowedOrigins = allowedOrigins
+
+	r := chi.NewRouter()
+	api := humachi.New(r, huma.DefaultConfig("dgop", "test"))
+	RegisterHandlers(&server.Server{Cfg: &cfg, Gops: gops.NewGopsUtil()}, huma.NewGroup(api, "/gops"))
+
+	ts := httptest.NewServer(r)
+	t.Cleanup(ts.Close)
+	return strings.TrimPrefix(ts.URL, "http://")
+}
+
+// handshake returns the status of a WebSocket upgrade sent with origin
+func handshake(t *testing.T, host, origin string) int {
+	req, err := http.NewRequest(http.MethodGet, "http://"+host+"/gops/stream/ws?modules=cpu", nil)
+	require.NoError(t, err)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestStreamWebSocketOrigin(t *testing.T) {
+	host := streamServer(t, "https://dash.example.com")
+
+	assert.Equal(t, http.StatusSwitchingProtocols, handshake(t, host, ""), "clients without an Origin")
+	assert.Equal(t, http.StatusSwitchingProtocols, handshake(t, host, "https://dash.example.com"))
+	assert.Equal(t, http.StatusForbidden, handshake(t, host, "https://evil.example.com"))
+	assert.Equal(t, http.StatusForbidden, handshake(t, streamServer(t), "https://dash.example.com"), "no CORS origins configured")
+	assert.Equal(t, http.StatusSwitchingProtocols, handshake(t, streamServer(t, "*"), "https://evil.example.com"))
+}
+
+func TestStreamWebSocketFrames(t *testing.T) {
+	host := streamServer(t, "https://dash.example.com")
+
+	conn, err := websocket.Dial("ws://"+host+"/gops/stream/ws?modules=cpu&interval=250", "", "https://dash.example.com")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	for range 2 {
+		var meta models.MetaInfo
+		require.NoError(t, websocket.JSON.Receive(conn, &meta))
+		require.NotNil(t, meta.CPU)
+		assert.NotEmpty(t, meta.CPU.Cursor)
+	}
+
+	_, err = websocket.Dial("ws://"+host+"/gops/stream/ws?modules=cpu", "", "https://evil.example.com")
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/AvengeMedia/dgop/config"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// Authenticate answers 401 unless the request carries one of the configured
+// API keys. Without keys every request is let through.
+func (self *Middleware) Authenticate(ctx huma.Context, next func(huma.Context)) {
+	if validAPIKey(self.cfg.Server.APIKeys, requestAPIKey(ctx.Header, ctx.Query)) {
+		next(ctx)
+		return
+	}
+
+	ctx.SetHeader("WWW-Authenticate", `Bearer realm="dgop"`)
+	huma.WriteErr(self.api, ctx, http.StatusUnauthorized, "Missing or invalid API key")
+}
+
+// RequireAPIKey is Authenticate for plain chi routes such as /metrics
+func RequireAPIKey(keys []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !validAPIKey(keys, requestAPIKey(r.Header.Get, r.URL.Query().Get)) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="dgop"`)
+				http.Error(w, "Missing or invalid API key", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requestAPIKey takes the key from an Authorization bearer token, X-API-Key,
+// or the api_key query parameter for EventSource and WebSocket clients that
+// can't set headers
+func requestAPIKey(header, query func(string) string) string {
+	if scheme, token, ok := strings.Cut(header("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	if key := header(APIKeyHeader); key != "" {
+		return key
+	}
+	return query("api_key")
+}
+
+func validAPIKey(keys []string, key string) bool {
+	if len(keys) == 0 {
+		return true
+	}
+
+	valid := false
+	for _, k := range keys {
+		// Compare against every key so timing doesn't tell which one matched
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// AllowClients answers 403 to clients outside prefixes, every client is
+// allowed when prefixes is empty. Proxy headers are only believed with
+// trustProxyHeaders, any client can send them.
+func AllowClients(prefixes []netip.Prefix, trustProxyHeaders bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(prefixes) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			addr, ok := clientAddr(r, trustProxyHeaders)
+			if ok {
+				for _, prefix := range prefixes {
+					if prefix.Contains(addr) {
+						next.ServeHTTP(w, r)
+						return
+					}
+				}
+			}
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		})
+	}
+}
+
+// AllowHosts answers 403 to requests for a Host other than localhost, an IP
+// address or the host the server listens on, unless API keys are set. A DNS
+// rebinding page reaches a loopback server under its own domain name, so
+// the browser lets it read the responses.
+func AllowHosts(cfg config.Server) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(cfg.APIKeys) > 0 {
+			return next
+		}
+
+		listenHost, _, _ := net.SplitHostPort(cfg.ListenAddr())
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !allowedHost(r.Host, listenHost) {
+				http.Error(w, "Forbidden host, set api_keys to serve other names", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func allowedHost(hostPort, listenHost string) bool {
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		host = strings.Trim(hostPort, "[]")
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	if host == "localhost" || (listenHost != "" && host == strings.ToLower(listenHost)) {
+		return true
+	}
+	// Rebinding needs a name to rebind, a bare address can't be one
+	_, err = netip.ParseAddr(host)
+	return err == nil
+}
+
+func clientAddr(r *http.Request, trustProxyHeaders bool) (netip.Addr, bool) {
+	raw := r.RemoteAddr
+	if trustProxyHeaders {
+		raw = proxyClientAddr(r)
+	}
+	raw = strings.TrimSpace(raw)
+
+	if addrPort, err := netip.ParseAddrPort(raw); err == nil {
+		return addrPort.Addr().Unmap(), true
+	}
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+// proxyClientAddr takes the client address from CF-Connecting-IP, X-Real-Ip
+// or X-Forwarded-For. Each proxy appends its peer to X-Forwarded-For, so only
+// the last entry was written by our proxy, the ones before it come from the
+// client and can say anything.
+func proxyClientAddr(r *http.Request) string {
+	for _, header := range []string{"CF-Connecting-IP", "X-Real-Ip"} {
+		if addr := r.Header.Get(header); addr != "" {
+			return addr
+		}
+	}
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		last := forwarded[len(forwarded)-1]
+		if i := strings.LastIndex(last, ","); i >= 0 {
+			last = last[i+1:]
+		}
+		return last
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/AvengeMedia/dgop/config"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(mw func(http.Handler) http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	r := chi.NewRouter()
+	r.Use(mw)
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRequireAPIKey(t *testing.T) {
+	mw := RequireAPIKey([]string{"first", "second"})
+
+	tests := map[string]struct {
+		setup  func(r *http.Request)
+		status int
+	}{
+		"Bearer token":     {func(r *http.Request) { r.Header.Set("Authorization", "Bearer second") }, http.StatusOK},
+		"Lowercase scheme": {func(r *http.Request) { r.Header.Set("Authorization", "bearer first") }, http.StatusOK},
+		"X-API-Key header": {func(r *http.Request) { r.Header.Set("X-API-Key", "first") }, http.StatusOK},
+		"Query parameter":  {func(r *http.Request) { r.URL.RawQuery = "api_key=first" }, http.StatusOK},
+		"Wrong key":        {func(r *http.Request) { r.Header.Set("Authorization", "Bearer third") }, http.StatusUnauthorized},
+		"Basic auth":       {func(r *http.Request) { r.SetBasicAuth("first", "") }, http.StatusUnauthorized},
+		"No key":           {func(r *http.Request) {}, http.StatusUnauthorized},
+	}
+
+	for name, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		test.setup(req)
+		w := serve(mw, req)
+		assert.Equal(t, test.status, w.Code, name)
+		if test.status == http.StatusUnauthorized {
+			assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"), name)
+		}
+	}
+
+	// No configured keys leaves the API open
+	w := serve(RequireAPIKey(nil), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAllowClients(t *testing.T) {
+	prefixes := []netip.Prefix{netip.MustParsePrefix("192.168.1.0/24"), netip.MustParsePrefix("::1/128")}
+
+	request := func(remoteAddr, forwardedFor string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		return req
+	}
+
+	direct := AllowClients(prefixes, false)
+	assert.Equal(t, http.StatusOK, serve(direct, request("192.168.1.20:50000", "")).Code)
+	assert.Equal(t, http.StatusOK, serve(direct, request("[::1]:50000", "")).Code)
+	assert.Equal(t, http.StatusOK, serve(direct, request("[::ffff:192.168.1.20]:50000", "")).Code)
+	assert.Equal(t, http.StatusForbidden, serve(direct, request("10.0.0.1:50000", "")).Code)
+	// Headers aren't trusted unless configured
+	assert.Equal(t, http.StatusForbidden, serve(direct, request("10.0.0.1:50000", "192.168.1.20")).Code)
+
+	proxied := AllowClients(prefixes, true)
+	assert.Equal(t, http.StatusOK, serve(proxied, request("10.0.0.1:50000", "192.168.1.20")).Code)
+	assert.Equal(t, http.StatusOK, serve(proxied, request("10.0.0.1:50000", "10.0.0.9, 192.168.1.20")).Code)
+	assert.Equal(t, http.StatusForbidden, serve(proxied, request("192.168.1.20:50000", "10.0.0.9")).Code)
+	// Entries before the one our proxy appended are the client's own words
+	assert.Equal(t, http.StatusForbidden, serve(proxied, request("10.0.0.1:50000", "192.168.1.20, 10.0.0.9")).Code)
+
+	// A proxy may append a second header rather than extend the first
+	req := request("10.0.0.1:50000", "192.168.1.20")
+	req.Header.Add("X-Forwarded-For", "10.0.0.9")
+	assert.Equal(t, http.StatusForbidden, serve(proxied, req).Code)
+
+	req = request("10.0.0.1:50000", "10.0.0.9")
+	req.Header.Set("X-Real-Ip", "192.168.1.20")
+	assert.Equal(t, http.StatusOK, serve(proxied, req).Code)
+
+	assert.Equal(t, http.StatusOK, serve(AllowClients(nil, false), request("10.0.0.1:50000", "")).Code)
+}
+
+func TestAllowHosts(t *testing.T) {
+	request := func(host string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Host = host
+		return req
+	}
+
+	cfg := config.Default().Server
+	mw := AllowHosts(cfg)
+	for _, host := range []string{"localhost:63484", "LOCALHOST", "localhost.:63484", "127.0.0.1:63484", "[::1]:63484", "192.168.1.20:63484"} {
+		assert.Equal(t, http.StatusOK, serve(mw, request(host)).Code, host)
+	}
+	// A rebound name pointing at 127.0.0.1
+	assert.Equal(t, http.StatusForbidden, serve(mw, request("evil.example:63484")).Code)
+	assert.Equal(t, http.StatusForbidden, serve(mw, request("")).Code)
+
+	cfg.Listen = "desktop.lan:63484"
+	assert.Equal(t, http.StatusOK, serve(AllowHosts(cfg), request("desktop.lan:63484")).Code)
+	assert.Equal(t, http.StatusForbidden, serve(AllowHosts(cfg), request("evil.example:63484")).Code)
+
+	// Keys stop a rebinding page on their own
+	cfg.APIKeys = []string{"key"}
+	assert.Equal(t, http.StatusOK, serve(AllowHosts(cfg), request("evil.example:63484")).Code)
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AvengeMedia/dgop/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	mw := CORS(config.CORS{
+		AllowedOrigins: []string{"https://dash.example.com"},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		MaxAge:         config.Duration{Duration: 5 * time.Minute},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://dash.example.com")
+	w := serve(mw, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://dash.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+
+	req = httptest.NewRequest(http.MethodOptions, "/", nil)
+	req.Header.Set("Origin", "https://dash.example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	w = serve(mw, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "Authorization, Content-Type", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "300", w.Header().Get("Access-Control-Max-Age"))
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	w = serve(mw, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	w = serve(CORS(config.CORS{AllowedOrigins: []string{"*"}}), req)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+
+	// Off without origins
+	w = serve(CORS(config.CORS{}), req)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"net/http"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/AvengeMedia/dgop/internal/log"
@@ -118,7 +119,7 @@ func (l *DefaultLogFormatter) NewLogEntry(r *http.Request) LogEntry {
 	if r.TLS != nil {
 		scheme = "https"
 	}
-	cW(entry.buf, useColor, nCyan, "%s://%s%s %s\" ", scheme, r.Host, r.RequestURI, r.Proto)
+	cW(entry.buf, useColor, nCyan, "%s://%s%s %s\" ", scheme, r.Host, redactAPIKey(r.RequestURI), r.Proto)
 
 	entry.buf.WriteString("from ")
 	entry.buf.WriteString(utils.GetIPAddress(r))
@@ -127,6 +128,23 @@ func (l *DefaultLogFormatter) NewLogEntry(r *http.Request) LogEntry {
 	return entry
 }
 
+// redactAPIKey hides the value of an api_key query parameter so keys sent by
+// EventSource and WebSocket clients don't end up in the log
+func redactAPIKey(uri string) string {
+	path, query, ok := strings.Cut(uri, "?")
+	if !ok {
+		return uri
+	}
+
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		if name, _, _ := strings.Cut(param, "="); name == "api_key" {
+			params[i] = "api_key=REDACTED"
+		}
+	}
+	return path + "?" + strings.Join(params, "&")
+}
+
 type defaultLogEntry struct {
 	*DefaultLogFormatter
 	request  *http.Request
@@ -51,3 +51,16 @@ func TestRequestLoggerReadFrom(t *testing.T) {
 
 	assert.Equal(t, data, w.Body.Bytes())
 }
+
+func TestRedactAPIKey(t *testing.T) {
+	tests := map[string]string{
+		"/gops/cpu":                             "/gops/cpu",
+		"/gops/stream?api_key=secret":           "/gops/stream?api_key=REDACTED",
+		"/gops/stream?modules=cpu&api_key=s&x=": "/gops/stream?modules=cpu&api_key=REDACTED&x=",
+		"/gops/stream?api_key=a&api_key=b":      "/gops/stream?api_key=REDACTED&api_key=REDACTED",
+		"/gops/stream?api_keys=kept":            "/gops/stream?api_keys=kept",
+	}
+	for uri, want := range tests {
+		assert.Equal(t, want, redactAPIKey(uri), uri)
+	}
+}
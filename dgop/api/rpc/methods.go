@@ -0,0 +1,296 @@
+package rpc
+
+import (
+	"encoding/json"
+
+	"github.com/AvengeMedia/dgop/gops"
+)
+
+type method func(params json.RawMessage) (any, *Error)
+
+type CursorParams struct {
+	Cursor string `json:"cursor"`
+}
+
+type ProcessParams struct {
+	SortBy         gops.ProcSortBy `json:"sort_by"`
+	Limit          int             `json:"limit"`
+	DisableProcCPU bool            `json:"disable_proc_cpu"`
+	Cursor         string          `json:"cursor"`
+	ProcessFilterParams
+}
+
+// ProcessFilterParams are shared by the process and meta methods
+type ProcessFilterParams struct {
+	Users             []string `json:"user"`
+	Command           string   `json:"command"`
+	Cmdline           string   `json:"cmdline"`
+	PIDs              []int32  `json:"pids"`
+	MinCPU            float64  `json:"min_cpu"`
+	MinMemory         float32  `json:"min_memory"`
+	HideKernelThreads bool     `json:"hide_kernel_threads"`
+	OnlyMine          bool     `json:"only_mine"`
+}
+
+func (p ProcessFilterParams) filter() gops.ProcessFilter {
+	return gops.ProcessFilter{
+		Usernames:         p.Users,
+		Command:           p.Command,
+		Cmdline:           p.Cmdline,
+		PIDs:              p.PIDs,
+		MinCPU:            p.MinCPU,
+		MinMemory:         p.MinMemory,
+		HideKernelThreads: p.HideKernelThreads,
+		OnlyMine:          p.OnlyMine,
+	}
+}
+
+// NetFilterParams and DiskFilterParams override the configured device
+// filters, see gops.DeviceFilter.Override
+type NetFilterParams struct {
+	NetInclude []string `json:"net_include"`
+	NetExclude []string `json:"net_exclude"`
+}
+
+func (p NetFilterParams) netFilter() gops.DeviceFilter {
+	return gops.DeviceFilter{Include: p.NetInclude, Exclude: p.NetExclude}
+}
+
+type DiskFilterParams struct {
+	DiskInclude []string `json:"disk_include"`
+	DiskExclude []string `json:"disk_exclude"`
+}
+
+func (p DiskFilterParams) diskFilter() gops.DeviceFilter {
+	return gops.DeviceFilter{Include: p.DiskInclude, Exclude: p.DiskExclude}
+}
+
+type NetRateParams struct {
+	Cursor string `json:"cursor"`
+	NetFilterParams
+}
+
+type DiskRateParams struct {
+	Cursor string `json:"cursor"`
+	DiskFilterParams
+}
+
+type AllParams struct {
+	SortBy         gops.ProcSortBy `json:"sort_by"`
+	Limit          int             `json:"limit"`
+	DisableProcCPU bool            `json:"disable_proc_cpu"`
+	CPUCursor      string          `json:"cpu_cursor"`
+	ProcCursor     string          `json:"proc_cursor"`
+}
+
+type MetaParams struct {
+	Modules        []string        `json:"modules"`
+	SortBy         gops.ProcSortBy `json:"sort_by"`
+	Limit          int             `json:"limit"`
+	DisableProcCPU bool            `json:"disable_proc_cpu"`
+	GPUPciIds      []string        `json:"gpu_pci_ids"`
+	CPUCursor      string          `json:"cpu_cursor"`
+	ProcCursor     string          `json:"proc_cursor"`
+	NetRateCursor  string          `json:"net_rate_cursor"`
+	DiskRateCursor string          `json:"disk_rate_cursor"`
+	PowerCursor    string          `json:"power_cursor"`
+	Listening      bool            `json:"listening"`
+	SkipFSTypes    []string        `json:"skip_fstypes"`
+	SMART          bool            `json:"smart"`
+	ProcessFilterParams
+	NetFilterParams
+	DiskFilterParams
+}
+
+type DiskMountsParams struct {
+	SkipFSTypes []string `json:"skip_fstypes"`
+}
+
+type BlockDeviceParams struct {
+	SMART bool `json:"smart"`
+}
+
+type ConnectionParams struct {
+	Protocols []string `json:"protocols"`
+	Listening bool     `json:"listening"`
+}
+
+type ProcessDetailParams struct {
+	PID int32 `json:"pid"`
+	Env bool  `json:"env"`
+}
+
+type SignalParams struct {
+	PID    int32  `json:"pid"`
+	Signal string `json:"signal"`
+}
+
+type ReniceParams struct {
+	PID  int32 `json:"pid"`
+	Nice int   `json:"nice"`
+}
+
+type GPUTempParams struct {
+	PciId string `json:"pci_id"`
+}
+
+type GPUInfoParams struct {
+	PciIds []string `json:"pci_ids"`
+}
+
+// Method names mirror the GopsUtil methods they call
+func (self *Server) registerMethods() map[string]method {
+	g := self.srv.Gops
+
+	return map[string]method{
+		"GetModules": noParams(func() (any, error) {
+			return g.GetModules()
+		}),
+		"GetMeta": withParams(func(p MetaParams) (any, error) {
+			if len(p.Modules) == 0 {
+				return nil, &Error{Code: CodeInvalidParams, Message: "modules is required"}
+			}
+			return g.GetMeta(p.Modules, gops.MetaParams{
+				SortBy:         sortByOrDefault(p.SortBy),
+				ProcLimit:      p.Limit,
+				EnableCPU:      !p.DisableProcCPU,
+				GPUPciIds:      p.GPUPciIds,
+				CPUCursor:      p.CPUCursor,
+				ProcCursor:     p.ProcCursor,
+				NetRateCursor:  p.NetRateCursor,
+				DiskRateCursor: p.DiskRateCursor,
+				ProcFilter:     p.filter(),
+				ListeningOnly:  p.Listening,
+				PowerCursor:    p.PowerCursor,
+				SkipFSTypes:    p.SkipFSTypes,
+				SMART:          p.SMART,
+				NetFilter:      p.netFilter(),
+				DiskFilter:     p.diskFilter(),
+			})
+		}),
+		"GetAllMetrics": withParams(func(p AllParams) (any, error) {
+			return g.GetAllMetricsWithCursors(sortByOrDefault(p.SortBy), p.Limit, !p.DisableProcCPU, p.CPUCursor, p.ProcCursor)
+		}),
+		"GetCPUInfo": withParams(func(p CursorParams) (any, error) {
+			return g.GetCPUInfoWithCursor(p.Cursor)
+		}),
+		"GetMemoryInfo": noParams(func() (any, error) {
+			return g.GetMemoryInfo()
+		}),
+		"GetNetworkInfo": withParams(func(p NetFilterParams) (any, error) {
+			return g.GetNetworkInfoFiltered(p.netFilter())
+		}),
+		"GetNetworkRates": withParams(func(p NetRateParams) (any, error) {
+			return g.GetNetworkRatesFiltered(p.Cursor, p.netFilter())
+		}),
+		"GetDiskInfo": withParams(func(p DiskFilterParams) (any, error) {
+			return g.GetDiskInfoFiltered(p.diskFilter())
+		}),
+		"GetDiskRates": withParams(func(p DiskRateParams) (any, error) {
+			return g.GetDiskRatesFiltered(p.Cursor, p.diskFilter())
+		}),
+		"GetDiskMounts": withParams(func(p DiskMountsParams) (any, error) {
+			return g.GetDiskMountsFiltered(p.SkipFSTypes)
+		}),
+		"GetBlockDevices": withParams(func(p BlockDeviceParams) (any, error) {
+			return g.GetBlockDevices(p.SMART)
+		}),
+		"GetProcessesWithCursor": withParams(func(p ProcessParams) (any, error) {
+			return g.GetFilteredProcesses(sortByOrDefault(p.SortBy), p.Limit, !p.DisableProcCPU, p.Cursor, p.filter())
+		}),
+		"GetProcessTree": withParams(func(p ProcessParams) (any, error) {
+			return g.GetProcessTree(sortByOrDefault(p.SortBy), p.Limit, !p.DisableProcCPU, p.Cursor, p.filter())
+		}),
+		"GetPowerInfo": withParams(func(p CursorParams) (any, error) {
+			return g.GetPowerInfo(p.Cursor)
+		}),
+		"GetSensors": noParams(func() (any, error) {
+			return g.GetSensors()
+		}),
+		"GetConnections": withParams(func(p ConnectionParams) (any, error) {
+			return g.GetConnections(p.Protocols, p.Listening)
+		}),
+		"GetProcessDetail": withParams(func(p ProcessDetailParams) (any, error) {
+			return g.GetProcessDetail(p.PID, p.Env)
+		}),
+		"SignalProcess": withParams(func(p SignalParams) (any, error) {
+			if p.Signal == "" {
+				p.Signal = "TERM"
+			}
+			sig, err := gops.ParseSignal(p.Signal)
+			if err != nil {
+				return nil, &Error{Code: CodeInvalidParams, Message: err.Error()}
+			}
+			if err := g.SignalProcess(p.PID, sig); err != nil {
+				return nil, err
+			}
+			return SignalParams{PID: p.PID, Signal: gops.SignalName(sig)}, nil
+		}),
+		"ReniceProcess": withParams(func(p ReniceParams) (any, error) {
+			if err := g.ReniceProcess(p.PID, p.Nice); err != nil {
+				return nil, err
+			}
+			return p, nil
+		}),
+		"GetSystemInfo": noParams(func() (any, error) {
+			return g.GetSystemInfo()
+		}),
+		"GetSystemHardware": noParams(func() (any, error) {
+			return g.GetSystemHardware()
+		}),
+		"GetSystemTemperatures": noParams(func() (any, error) {
+			return g.GetSystemTemperatures()
+		}),
+		"GetTemperatures": noParams(func() (any, error) {
+			return g.GetTemperatures()
+		}),
+		"GetGPUInfo": withParams(func(p GPUInfoParams) (any, error) {
+			return g.GetGPUInfoWithTemp(p.PciIds)
+		}),
+		"GetGPUTemp": withParams(func(p GPUTempParams) (any, error) {
+			if p.PciId == "" {
+				return nil, &Error{Code: CodeInvalidParams, Message: "pci_id is required"}
+			}
+			return g.GetGPUTemp(p.PciId)
+		}),
+		"GetGPUUsage": noParams(func() (any, error) {
+			return g.GetGPUUsage()
+		}),
+	}
+}
+
+func noParams(fn func() (any, error)) method {
+	return func(_ json.RawMessage) (any, *Error) {
+		return toRPCResult(fn())
+	}
+}
+
+// withParams decodes by-name params into P. Omitted params leave P zeroed.
+func withParams[P any](fn func(P) (any, error)) method {
+	return func(raw json.RawMessage) (any, *Error) {
+		var p P
+		if len(raw) > 0 && string(raw) != "null" {
+			if err := json.Unmarshal(raw, &p); err != nil {
+				return nil, &Error{Code: CodeInvalidParams, Message: "Invalid params: " + err.Error()}
+			}
+		}
+		return toRPCResult(fn(p))
+	}
+}
+
+func toRPCResult(result any, err error) (any, *Error) {
+	if err == nil {
+		return result, nil
+	}
+	if rpcErr, ok := err.(*Error); ok {
+		return nil, rpcErr
+	}
+	return nil, &Error{Code: CodeServerError, Message: err.Error()}
+}
+
+func sortByOrDefault(sortBy gops.ProcSortBy) gops.ProcSortBy {
+	if sortBy == "" {
+		return gops.SortByCPU
+	}
+	return sortBy
+}
@@ -0,0 +1,210 @@
+package rpc
+
+// JSON-RPC 2.0 over a stream connection, used for the unix domain socket.
+// Requests and responses are JSON values written back to back on the stream
+// (one per line on the way out); batches are supported.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/AvengeMedia/dgop/api/server"
+	"github.com/AvengeMedia/dgop/internal/log"
+)
+
+const Version = "2.0"
+
+// Standard JSON-RPC 2.0 error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	CodeServerError    = -32000
+)
+
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+type Server struct {
+	srv     *server.Server
+	methods map[string]method
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+func NewServer(srv *server.Server) *Server {
+	s := &Server{
+		srv:   srv,
+		conns: make(map[net.Conn]struct{}),
+	}
+	s.methods = s.registerMethods()
+	return s
+}
+
+// Serve accepts connections until ctx is done, then closes the listener
+// and every open connection.
+func (self *Server) Serve(ctx context.Context, l net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		l.Close()
+		self.mu.Lock()
+		for conn := range self.conns {
+			conn.Close()
+		}
+		self.mu.Unlock()
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		self.mu.Lock()
+		self.conns[conn] = struct{}{}
+		self.mu.Unlock()
+
+		go func() {
+			self.ServeConn(conn)
+			self.mu.Lock()
+			delete(self.conns, conn)
+			self.mu.Unlock()
+		}()
+	}
+}
+
+// ServeConn answers requests on a single connection until it is closed
+func (self *Server) ServeConn(conn io.ReadWriteCloser) {
+	defer conn.Close()
+
+	dec := json.NewDecoder(conn)
+	enc := json.NewEncoder(conn)
+
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			// The stream can't be resynchronised after malformed JSON
+			enc.Encode(errorResponse(nil, CodeParseError, "Parse error"))
+			return
+		}
+
+		if resp := self.handleMessage(raw); resp != nil {
+			if err := enc.Encode(resp); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// handleMessage dispatches a single request or a batch. A nil return means
+// there is nothing to send back (notifications only).
+func (self *Server) handleMessage(raw json.RawMessage) any {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(trimmed, &batch); err != nil || len(batch) == 0 {
+			return errorResponse(nil, CodeInvalidRequest, "Invalid Request")
+		}
+
+		responses := make([]*Response, 0, len(batch))
+		for _, item := range batch {
+			if resp := self.handleRequest(item); resp != nil {
+				responses = append(responses, resp)
+			}
+		}
+		if len(responses) == 0 {
+			return nil
+		}
+		return responses
+	}
+
+	if resp := self.handleRequest(trimmed); resp != nil {
+		return resp
+	}
+	return nil
+}
+
+func (self *Server) handleRequest(raw json.RawMessage) *Response {
+	var req Request
+	if err := json.Unmarshal(raw, &req); err != nil || req.JSONRPC != Version || req.Method == "" {
+		return errorResponse(req.ID, CodeInvalidRequest, "Invalid Request")
+	}
+
+	result, rpcErr := self.call(req.Method, req.Params)
+
+	// Notifications have no id and get no response
+	if req.ID == nil {
+		return nil
+	}
+
+	if rpcErr != nil {
+		return &Response{JSONRPC: Version, Error: rpcErr, ID: req.ID}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(req.ID, CodeInternalError, "Internal error")
+	}
+	return &Response{JSONRPC: Version, Result: data, ID: req.ID}
+}
+
+func (self *Server) call(name string, params json.RawMessage) (result any, rpcErr *Error) {
+	m, ok := self.methods[name]
+	if !ok {
+		return nil, &Error{Code: CodeMethodNotFound, Message: "Method not found: " + name}
+	}
+
+	defer func() {
+		if rvr := recover(); rvr != nil {
+			log.Errorf("Panic in JSON-RPC method %s: %v", name, rvr)
+			result, rpcErr = nil, &Error{Code: CodeInternalError, Message: "Internal error"}
+		}
+	}()
+
+	return m(params)
+}
+
+func errorResponse(id json.RawMessage, code int, message string) *Response {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return &Response{
+		JSONRPC: Version,
+		Error:   &Error{Code: code, Message: message},
+		ID:      id,
+	}
+}
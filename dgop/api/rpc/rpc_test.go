@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/AvengeMedia/dgop/api/server"
+	"github.com/AvengeMedia/dgop/gops"
+	"github.com/stretchr/testify/assert"
+)
+
+func roundTrip(t *testing.T, request string) string {
+	client, conn := net.Pipe()
+	s := NewServer(&server.Server{Gops: gops.NewGopsUtil()})
+	go s.ServeConn(conn)
+	defer client.Close()
+
+	go client.Write([]byte(request + "\n"))
+
+	line, err := bufio.NewReader(client).ReadString('\n')
+	assert.NoError(t, err)
+	return line
+}
+
+func TestServeConn(t *testing.T) {
+	tests := map[string]struct {
+		request      string
+		expectedCode int
+		expectedID   string
+	}{
+		"Calls a method": {
+			`{"jsonrpc":"2.0","id":1,"method":"GetModules"}`,
+			0,
+			"1",
+		},
+		"Unknown method": {
+			`{"jsonrpc":"2.0","id":"a","method":"Shutdown"}`,
+			CodeMethodNotFound,
+			`"a"`,
+		},
+		"Missing version": {
+			`{"id":2,"method":"GetModules"}`,
+			CodeInvalidRequest,
+			"2",
+		},
+		"Invalid params": {
+			`{"jsonrpc":"2.0","id":3,"method":"GetCPUInfo","params":{"cursor":5}}`,
+			CodeInvalidParams,
+			"3",
+		},
+	}
+
+	for name, test := range tests {
+		var resp Response
+		line := roundTrip(t, test.request)
+		assert.NoError(t, json.Unmarshal([]byte(line), &resp), name)
+		assert.Equal(t, Version, resp.JSONRPC, name)
+		assert.Equal(t, test.expectedID, string(resp.ID), name)
+		if test.expectedCode == 0 {
+			assert.Nil(t, resp.Error, name)
+			assert.Contains(t, string(resp.Result), `"available"`, name)
+		} else {
+			assert.NotNil(t, resp.Error, name)
+			assert.Equal(t, test.expectedCode, resp.Error.Code, name)
+		}
+	}
+}
+
+func TestServeConnBatch(t *testing.T) {
+	line := roundTrip(t, `[{"jsonrpc":"2.0","id":1,"method":"GetModules"},{"jsonrpc":"2.0","method":"GetModules"},{"jsonrpc":"2.0","id":2,"method":"GetGPUTemp"}]`)
+
+	var resps []Response
+	assert.NoError(t, json.Unmarshal([]byte(line), &resps))
+	// The notification gets no response
+	assert.Len(t, resps, 2)
+	assert.Equal(t, "1", string(resps[0].ID))
+	assert.Nil(t, resps[0].Error)
+	assert.Equal(t, "2", string(resps[1].ID))
+	assert.Equal(t, CodeInvalidParams, resps[1].Error.Code)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AvengeMedia/dgop/gops"
+	"github.com/spf13/cobra"
+)
+
+var killCmd = &cobra.Command{
+	Use:   "kill <pattern|pid>",
+	Short: "Send a signal to processes",
+	Long:  "Send a signal (TERM by default) to a PID or to every process whose name matches a regular expression.",
+	Args:  cobra.ExactArgs(1),
+}
+
+// reniceCmd takes the value as -n like util-linux renice, a negative
+// positional value would be read as flags
+var reniceCmd = &cobra.Command{
+	Use:   "renice -n <nice> <pattern|pid>",
+	Short: "Change the nice value of processes",
+	Long:  "Set the nice value (-20 to 19) of a PID or of every process whose name matches a regular expression.",
+	Args:  cobra.ExactArgs(1),
+}
+
+type processActionResult struct {
+	PID     int32  `json:"pid"`
+	Command string `json:"command"`
+	Action  string `json:"action"`
+	DryRun  bool   `json:"dryRun,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func runKillCommand(gopsUtil *gops.GopsUtil, pattern string) error {
+	sig, err := gops.ParseSignal(killSignal)
+	if err != nil {
+		return err
+	}
+
+	return runProcessAction(gopsUtil, pattern, gops.SignalName(sig), func(pid int32) error {
+		return gopsUtil.SignalProcess(pid, sig)
+	})
+}
+
+func runReniceCommand(gopsUtil *gops.GopsUtil, nice int, pattern string) error {
+	return runProcessAction(gopsUtil, pattern, fmt.Sprintf("nice %d", nice), func(pid int32) error {
+		return gopsUtil.ReniceProcess(pid, nice)
+	})
+}
+
+// runProcessAction applies act to every process matching pattern, or only
+// lists them with --dry-run
+func runProcessAction(gopsUtil *gops.GopsUtil, pattern, action string, act func(pid int32) error) error {
+	procs, err := gopsUtil.MatchProcesses(pattern, matchFull)
+	if err != nil {
+		return err
+	}
+	if len(procs) == 0 {
+		return fmt.Errorf("no processes match %q", pattern)
+	}
+
+	results := make([]processActionResult, 0, len(procs))
+	failed := 0
+	for _, proc := range procs {
+		result := processActionResult{
+			PID:     proc.PID,
+			Command: proc.Command,
+			Action:  action,
+			DryRun:  dryRun,
+		}
+		if !dryRun {
+			if err := act(proc.PID); err != nil {
+				result.Error = err.Error()
+				failed++
+			}
+		}
+		results = append(results, result)
+	}
+
+	if jsonOutput {
+		if err := outputJSON(results); err != nil {
+			return err
+		}
+	} else {
+		displayProcessActions(results)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed on %d of %d processes", failed, len(results))
+	}
+	return nil
+}
+
+func displayProcessActions(results []processActionResult) {
+	header := fmt.Sprintf("%-8s %-20s %-10s %s", "PID", "COMMAND", "ACTION", "RESULT")
+	fmt.Println(keyStyle.Render(header))
+	fmt.Println(strings.Repeat("─", 60))
+
+	for _, r := range results {
+		status := "ok"
+		switch {
+		case r.DryRun:
+			status = "dry run"
+		case r.Error != "":
+			status = r.Error
+		}
+		row := fmt.Sprintf("%-8d %-20s %-10s %s", r.PID, truncateString(r.Command, 20), r.Action, status)
+		fmt.Println(valueStyle.Render(row))
+	}
+}
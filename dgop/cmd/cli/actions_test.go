@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReniceAcceptsNegativeNice(t *testing.T) {
+	tests := map[string][]string{
+		"short":          {"-n", "-5", "sleep", "--dry-run"},
+		"short attached": {"-n-5", "sleep"},
+		"long":           {"--nice", "-5", "sleep"},
+		"long equals":    {"--nice=-5", "sleep"},
+		"after pattern":  {"sleep", "-n", "-5"},
+	}
+	for name, args := range tests {
+		reniceNice = 0
+		require.NoError(t, reniceCmd.ParseFlags(args), name)
+		assert.Equal(t, -5, reniceNice, name)
+		assert.Equal(t, []string{"sleep"}, reniceCmd.Flags().Args(), name)
+		assert.NoError(t, reniceCmd.ValidateArgs(reniceCmd.Flags().Args()), name)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AvengeMedia/dgop/gops"
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/spf13/cobra"
+)
+
+var blockDevicesCmd = &cobra.Command{
+	Use:     "blockdevices",
+	Aliases: []string{"lsblk"},
+	Short:   "Get block devices",
+	Long:    "Display block devices with model, serial, scheduler, partitions and the dm/LVM/LUKS devices stacked on them. --smart adds SMART health through smartctl.",
+}
+
+func runBlockDevicesCommand(gopsUtil *gops.GopsUtil) error {
+	devices, err := gopsUtil.GetBlockDevices(blockSMART)
+	if err != nil {
+		return fmt.Errorf("failed to get block devices: %w", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(devices)
+	}
+
+	displayBlockDevices(devices)
+	return nil
+}
+
+func displayBlockDevices(devices []*models.BlockDevice) {
+	fmt.Println(titleStyle.Render("BLOCK DEVICES"))
+
+	if len(devices) == 0 {
+		fmt.Println(valueStyle.Render("  No block devices found"))
+		return
+	}
+
+	for i, device := range devices {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		name := fmt.Sprintf("%s (%s, %s)", device.Name, device.Type, formatBytes(device.Size))
+		if device.DMName != "" {
+			name = fmt.Sprintf("%s (%s %s, %s)", device.Name, device.DMType, device.DMName, formatBytes(device.Size))
+		}
+		fmt.Println(keyStyle.Render(name))
+
+		rows := [][]string{}
+		if model := strings.TrimSpace(device.Vendor + " " + device.Model); model != "" {
+			rows = append(rows, []string{"Model:", model})
+		}
+		if device.Serial != "" {
+			rows = append(rows, []string{"Serial:", device.Serial})
+		}
+		if device.Type == "disk" {
+			media := "SSD"
+			if device.Rotational {
+				media = "HDD"
+			}
+			if device.Removable {
+				media += ", removable"
+			}
+			rows = append(rows, []string{"Media:", media})
+		}
+		if device.ReadOnly {
+			rows = append(rows, []string{"Read-only:", "yes"})
+		}
+		if device.Scheduler != "" {
+			rows = append(rows, []string{"Scheduler:", device.Scheduler})
+		}
+		if device.QueueDepth > 0 {
+			rows = append(rows, []string{"Queue depth:", fmt.Sprintf("%d", device.QueueDepth)})
+		}
+		if len(device.Slaves) > 0 {
+			rows = append(rows, []string{"On top of:", strings.Join(device.Slaves, ", ")})
+		}
+		if len(device.Holders) > 0 {
+			rows = append(rows, []string{"Holders:", strings.Join(device.Holders, ", ")})
+		}
+		if smart := device.SMART; smart != nil {
+			health := "FAILED"
+			if smart.Passed {
+				health = "PASSED"
+			}
+			rows = append(rows, []string{"SMART:", fmt.Sprintf("%s, %s, %d hours, %d power cycles", health, formatTemperature("%.0f", smart.Temperature), smart.PowerOnHours, smart.PowerCycles)})
+			if smart.PercentageUsed > 0 {
+				rows = append(rows, []string{"Wear:", fmt.Sprintf("%d%%", smart.PercentageUsed)})
+			}
+		}
+		printTable(rows)
+
+		for _, part := range device.Partitions {
+			line := fmt.Sprintf("  └ %-14s %10s", part.Name, formatBytes(part.Size))
+			if len(part.Holders) > 0 {
+				line += "  → " + strings.Join(part.Holders, ", ")
+			}
+			fmt.Println(valueStyle.Render(line))
+		}
+	}
+}
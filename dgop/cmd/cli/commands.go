@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/AvengeMedia/dgop/gops"
 	"github.com/AvengeMedia/dgop/models"
@@ -67,6 +69,13 @@ var processesCmd = &cobra.Command{
 	Long:  "Display information about running processes with sorting and filtering options.",
 }
 
+var processCmd = &cobra.Command{
+	Use:   "process <pid>",
+	Short: "Inspect a single process",
+	Long:  "Display state, limits, open files, cgroup, namespaces and I/O counters of a process.",
+	Args:  cobra.ExactArgs(1),
+}
+
 var systemCmd = &cobra.Command{
 	Use:   "system",
 	Short: "Get general system information",
@@ -91,6 +100,18 @@ var gpuTempCmd = &cobra.Command{
 	Long:  "Get temperature for a specific GPU by PCI ID (e.g., --pci-id 10de:2684).",
 }
 
+var gpuUsageCmd = &cobra.Command{
+	Use:   "gpu-usage",
+	Short: "Get GPU utilization, VRAM and clocks",
+	Long:  "Display utilization, VRAM, clocks and power draw of each GPU, from sysfs for amdgpu and Intel and from nvidia-smi for nvidia.",
+}
+
+var powerCmd = &cobra.Command{
+	Use:   "power",
+	Short: "Get battery and power adapter information",
+	Long:  "Display battery charge, health and time estimates and whether the power adapter is connected.",
+}
+
 var metaCmd = &cobra.Command{
 	Use:   "meta",
 	Short: "Get dynamic system metrics",
@@ -155,7 +176,7 @@ func runMemoryCommand(gopsUtil *gops.GopsUtil) error {
 }
 
 func runNetworkCommand(gopsUtil *gops.GopsUtil) error {
-	networkInfo, err := gopsUtil.GetNetworkInfo()
+	networkInfo, err := gopsUtil.GetNetworkInfoFiltered(netFilterFromFlags())
 	if err != nil {
 		return fmt.Errorf("failed to get network info: %w", err)
 	}
@@ -169,12 +190,12 @@ func runNetworkCommand(gopsUtil *gops.GopsUtil) error {
 }
 
 func runDiskCommand(gopsUtil *gops.GopsUtil) error {
-	diskInfo, err := gopsUtil.GetDiskInfo()
+	diskInfo, err := gopsUtil.GetDiskInfoFiltered(diskFilterFromFlags())
 	if err != nil {
 		return fmt.Errorf("failed to get disk info: %w", err)
 	}
 
-	diskMounts, err := gopsUtil.GetDiskMounts()
+	diskMounts, err := gopsUtil.GetDiskMountsFiltered(skipFSTypes)
 	if err != nil {
 		return fmt.Errorf("failed to get disk mounts: %w", err)
 	}
@@ -198,7 +219,21 @@ func runProcessesCommand(gopsUtil *gops.GopsUtil) error {
 	enableCPU := !disableProcCPU
 	sortBy := parseProcessSortBy(procSortBy, disableProcCPU)
 
-	result, err := gopsUtil.GetProcessesWithCursor(sortBy, procLimit, enableCPU, procCursor)
+	if procTree {
+		result, err := gopsUtil.GetProcessTree(sortBy, procLimit, enableCPU, procCursor, processFilterFromFlags())
+		if err != nil {
+			return fmt.Errorf("failed to get process tree: %w", err)
+		}
+
+		if jsonOutput {
+			return outputJSON(result)
+		}
+
+		displayProcessTree(result.Processes)
+		return nil
+	}
+
+	result, err := gopsUtil.GetFilteredProcesses(sortBy, procLimit, enableCPU, procCursor, processFilterFromFlags())
 	if err != nil {
 		return fmt.Errorf("failed to get processes: %w", err)
 	}
@@ -211,6 +246,25 @@ func runProcessesCommand(gopsUtil *gops.GopsUtil) error {
 	return nil
 }
 
+func runProcessCommand(gopsUtil *gops.GopsUtil, pidArg string) error {
+	pid, err := strconv.ParseInt(pidArg, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid pid %q", pidArg)
+	}
+
+	detail, err := gopsUtil.GetProcessDetail(int32(pid), showEnv)
+	if err != nil {
+		return fmt.Errorf("failed to get process: %w", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(detail)
+	}
+
+	displayProcessDetail(detail)
+	return nil
+}
+
 func runSystemCommand(gopsUtil *gops.GopsUtil) error {
 	systemInfo, err := gopsUtil.GetSystemInfo()
 	if err != nil {
@@ -267,6 +321,20 @@ func runGPUTempCommand(gopsUtil *gops.GopsUtil) error {
 	return nil
 }
 
+func runGPUUsageCommand(gopsUtil *gops.GopsUtil) error {
+	gpuUsage, err := gopsUtil.GetGPUUsage()
+	if err != nil {
+		return fmt.Errorf("failed to get GPU usage: %w", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(gpuUsage)
+	}
+
+	displayGPUUsage(gpuUsage)
+	return nil
+}
+
 func runMetaCommand(gopsUtil *gops.GopsUtil) error {
 	params := gops.MetaParams{
 		SortBy:         parseProcessSortBy(procSortBy, disableProcCPU),
@@ -277,6 +345,13 @@ func runMetaCommand(gopsUtil *gops.GopsUtil) error {
 		ProcCursor:     procCursor,
 		NetRateCursor:  netRateCursor,
 		DiskRateCursor: diskRateCursor,
+		ProcFilter:     processFilterFromFlags(),
+		ListeningOnly:  connListening,
+		PowerCursor:    powerCursor,
+		SkipFSTypes:    skipFSTypes,
+		SMART:          blockSMART,
+		NetFilter:      netFilterFromFlags(),
+		DiskFilter:     diskFilterFromFlags(),
 	}
 
 	metaInfo, err := gopsUtil.GetMeta(metaModules, params)
@@ -355,6 +430,71 @@ func displaySystemInfo(info *models.SystemInfo) {
 	printTable(rows)
 }
 
+func displayPowerInfo(power *models.PowerInfo) {
+	fmt.Println(titleStyle.Render("POWER"))
+
+	if len(power.Batteries) == 0 && len(power.Adapters) == 0 {
+		fmt.Println(valueStyle.Render("  No power supplies found"))
+		return
+	}
+
+	onBattery := "no"
+	if power.OnBattery {
+		onBattery = "yes"
+	}
+	rows := [][]string{{"On Battery:", onBattery}}
+	for _, adapter := range power.Adapters {
+		state := "offline"
+		if adapter.Online {
+			state = "online"
+		}
+		rows = append(rows, []string{adapter.Name + ":", fmt.Sprintf("%s (%s)", state, adapter.Type)})
+	}
+	printTable(rows)
+
+	for _, battery := range power.Batteries {
+		fmt.Println()
+		name := battery.Name
+		if model := strings.TrimSpace(battery.Manufacturer + " " + battery.Model); model != "" {
+			name = fmt.Sprintf("%s (%s)", name, model)
+		}
+		fmt.Println(keyStyle.Render(name))
+
+		rows := [][]string{
+			{"Status:", battery.Status},
+			{"Capacity:", fmt.Sprintf("%d%%", battery.Capacity)},
+		}
+		if battery.EnergyFull > 0 {
+			rows = append(rows, []string{"Energy:", fmt.Sprintf("%.1f / %.1f Wh", battery.EnergyNow, battery.EnergyFull)})
+		}
+		if battery.HealthPercent > 0 {
+			rows = append(rows, []string{"Health:", fmt.Sprintf("%.0f%% of %.1f Wh design", battery.HealthPercent, battery.EnergyFullDesign)})
+		}
+		if battery.PowerNow > 0 {
+			rows = append(rows, []string{"Power:", fmt.Sprintf("%.1f W", battery.PowerNow)})
+		}
+		if battery.TimeToEmpty > 0 {
+			rows = append(rows, []string{"Time to Empty:", formatDuration(time.Duration(battery.TimeToEmpty) * time.Second)})
+		}
+		if battery.TimeToFull > 0 {
+			rows = append(rows, []string{"Time to Full:", formatDuration(time.Duration(battery.TimeToFull) * time.Second)})
+		}
+		if battery.CycleCount > 0 {
+			rows = append(rows, []string{"Cycles:", strconv.Itoa(battery.CycleCount)})
+		}
+		printTable(rows)
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %02dm", hours, minutes)
+}
+
 func displayCPUInfo(cpu *models.CPUInfo) {
 	fmt.Println(titleStyle.Render("CPU"))
 
@@ -362,7 +502,7 @@ func displayCPUInfo(cpu *models.CPUInfo) {
 		{"Count:", strconv.Itoa(cpu.Count)},
 		{"Model:", cpu.Model},
 		{"Frequency:", fmt.Sprintf("%.2f MHz", cpu.Frequency)},
-		{"Temperature:", fmt.Sprintf("%.2f°C", cpu.Temperature)},
+		{"Temperature:", formatTemperature("%.2f", cpu.Temperature)},
 		{"Usage:", fmt.Sprintf("%.1f%%", cpu.Usage)},
 	}
 
@@ -406,6 +546,31 @@ func displayMemoryInfo(mem *models.MemoryInfo) {
 		rows = append(rows, []string{"Swap Used:", fmt.Sprintf("%.2f GB", swapUsedGB)})
 	}
 
+	if ext := mem.Extended; ext != nil {
+		kb := func(v uint64) string { return formatBytes(v * 1024) }
+		rows = append(rows,
+			[]string{"Dirty:", fmt.Sprintf("%s (writeback %s)", kb(ext.Dirty), kb(ext.Writeback))},
+			[]string{"Slab:", fmt.Sprintf("%s (reclaimable %s)", kb(ext.Slab), kb(ext.SReclaimable))},
+			[]string{"Anon / Mapped:", fmt.Sprintf("%s / %s", kb(ext.AnonPages), kb(ext.Mapped))},
+			[]string{"Shmem:", kb(ext.Shmem)},
+			[]string{"Committed:", fmt.Sprintf("%s of %s limit", kb(ext.CommittedAS), kb(ext.CommitLimit))},
+		)
+		if ext.HugePagesTotal > 0 || ext.AnonHugePages > 0 {
+			rows = append(rows, []string{"Huge Pages:", fmt.Sprintf("%d/%d free of %s, THP %s",
+				ext.HugePagesFree, ext.HugePagesTotal, kb(ext.HugePageSize), kb(ext.AnonHugePages))})
+		}
+	}
+
+	for _, zram := range mem.Zram {
+		rows = append(rows, []string{zram.Name + ":", fmt.Sprintf("%s stored in %s (%.2fx, %s)",
+			formatBytes(zram.OrigDataSize*1024), formatBytes(zram.MemUsedTotal*1024), zram.CompressionRatio, zram.Algorithm)})
+	}
+
+	if zswap := mem.Zswap; zswap != nil && zswap.Enabled {
+		rows = append(rows, []string{"Zswap:", fmt.Sprintf("%s stored in %s (%.2fx, %s)",
+			formatBytes(zswap.StoredSize*1024), formatBytes(zswap.PoolSize*1024), zswap.CompressionRatio, zswap.Compressor)})
+	}
+
 	printTable(rows)
 }
 
@@ -417,12 +582,40 @@ func displayNetworkInfo(interfaces []*models.NetworkInfo) {
 			fmt.Println()
 		}
 
-		fmt.Println(keyStyle.Render(fmt.Sprintf("Interface: %s", iface.Name)))
+		fmt.Println(keyStyle.Render(fmt.Sprintf("Interface: %s (%s)", iface.Name, iface.Type)))
 
-		rows := [][]string{
-			{"Bytes Received:", formatBytes(iface.Rx)},
-			{"Bytes Sent:", formatBytes(iface.Tx)},
+		state := iface.OperState
+		if iface.Carrier {
+			state += ", carrier"
+		}
+		if iface.Speed > 0 {
+			state += fmt.Sprintf(", %d Mbit/s", iface.Speed)
+			if iface.Duplex != "" {
+				state += " " + iface.Duplex + " duplex"
+			}
+		}
+		rows := [][]string{{"State:", state}}
+		if iface.Driver != "" {
+			rows = append(rows, []string{"Driver:", iface.Driver})
+		}
+		if iface.MAC != "" {
+			rows = append(rows, []string{"MAC:", iface.MAC})
 		}
+		rows = append(rows, []string{"MTU:", fmt.Sprintf("%d", iface.MTU)})
+		for _, addr := range iface.Addresses {
+			label := "IPv4:"
+			if addr.Family == "ipv6" {
+				label = "IPv6:"
+			}
+			rows = append(rows, []string{label, fmt.Sprintf("%s/%d", addr.Address, addr.Prefix)})
+		}
+		if w := iface.Wireless; w != nil {
+			rows = append(rows, []string{"Signal:", fmt.Sprintf("%.0f dBm, link quality %.0f", w.SignalLevel, w.LinkQuality)})
+		}
+		rows = append(rows,
+			[]string{"Bytes Received:", formatBytes(iface.Rx)},
+			[]string{"Bytes Sent:", formatBytes(iface.Tx)},
+		)
 
 		printTable(rows)
 	}
@@ -454,12 +647,17 @@ func displayDiskInfo(disks []*models.DiskInfo, mounts []*models.DiskMountInfo) {
 		fmt.Println(keyStyle.Render("Mount Points:"))
 
 		for _, mount := range mounts {
-			fmt.Printf("  %s → %s (%s) [%s used, %s available]\n",
+			fstype := mount.FSType
+			if mount.ReadOnly {
+				fstype += ", ro"
+			}
+			fmt.Printf("  %s → %s (%s) [%s used, %s available, %.0f%% inodes]\n",
 				valueStyle.Render(mount.Device),
 				valueStyle.Render(mount.Mount),
-				valueStyle.Render(mount.FSType),
+				valueStyle.Render(fstype),
 				valueStyle.Render(mount.Used+" ("+mount.Percent+")"),
-				valueStyle.Render(mount.Avail))
+				valueStyle.Render(mount.Avail),
+				mount.InodesUsedPercent)
 		}
 	}
 }
@@ -485,6 +683,108 @@ func displayProcesses(processes []*models.ProcessInfo) {
 	}
 }
 
+func displayProcessTree(roots []*models.ProcessTreeNode) {
+	rows := gops.FlattenProcessTree(roots)
+	fmt.Println(titleStyle.Render(fmt.Sprintf("PROCESS TREE (%d)", len(rows))))
+
+	// Header
+	header := fmt.Sprintf("%-8s %-8s %-8s %-10s %-10s %s",
+		"PID", "CPU%", "MEM%", "TREE CPU%", "TREE MEM", "COMMAND")
+	fmt.Println(keyStyle.Render(header))
+	fmt.Println(strings.Repeat("─", 80))
+
+	for _, row := range rows {
+		line := fmt.Sprintf("%-8d %-8.1f %-8.1f %-10.1f %-10s %s%s",
+			row.Node.PID,
+			row.Node.CPU,
+			row.Node.MemoryPercent,
+			row.Node.SubtreeCPU,
+			formatBytes(row.Node.SubtreeMemoryKB*1024),
+			row.Prefix,
+			truncateString(row.Node.Command, 30))
+		fmt.Println(valueStyle.Render(line))
+	}
+}
+
+func displayProcessDetail(detail *models.ProcessDetail) {
+	fmt.Println(titleStyle.Render(fmt.Sprintf("PROCESS %d", detail.PID)))
+
+	rows := [][]string{
+		{"Command:", detail.Command},
+		{"Full Command:", detail.FullCommand},
+		{"PPID:", strconv.Itoa(int(detail.PPID))},
+		{"User:", detail.Username},
+		{"State:", fmt.Sprintf("%s (%s)", detail.State, detail.StateDescription)},
+		{"Started:", time.UnixMilli(detail.StartTime).Format("2006-01-02 15:04:05")},
+		{"Nice:", strconv.Itoa(detail.Nice)},
+		{"Priority:", strconv.Itoa(detail.Priority)},
+		{"Threads:", strconv.Itoa(detail.Threads)},
+		{"Exe:", detail.Exe},
+		{"Cwd:", detail.Cwd},
+		{"Cgroup:", detail.Cgroup},
+	}
+	printTable(rows)
+
+	if detail.IO != nil {
+		fmt.Println()
+		fmt.Println(titleStyle.Render("I/O"))
+		printTable([][]string{
+			{"Read:", formatBytes(detail.IO.ReadBytes)},
+			{"Written:", formatBytes(detail.IO.WriteBytes)},
+			{"Read (all):", formatBytes(detail.IO.ReadChars)},
+			{"Written (all):", formatBytes(detail.IO.WriteChars)},
+			{"Read Syscalls:", strconv.FormatUint(detail.IO.ReadSyscalls, 10)},
+			{"Write Syscalls:", strconv.FormatUint(detail.IO.WriteSyscalls, 10)},
+		})
+	}
+
+	if len(detail.Namespaces) > 0 {
+		fmt.Println()
+		fmt.Println(titleStyle.Render("NAMESPACES"))
+		names := make([]string, 0, len(detail.Namespaces))
+		for name := range detail.Namespaces {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		nsRows := make([][]string, 0, len(names))
+		for _, name := range names {
+			nsRows = append(nsRows, []string{name + ":", strconv.FormatUint(detail.Namespaces[name], 10)})
+		}
+		printTable(nsRows)
+	}
+
+	if len(detail.Rlimits) > 0 {
+		fmt.Println()
+		fmt.Println(titleStyle.Render("LIMITS"))
+		fmt.Println(keyStyle.Render(fmt.Sprintf("%-26s %-14s %-14s %s", "RESOURCE", "SOFT", "HARD", "UNIT")))
+		for _, limit := range detail.Rlimits {
+			row := fmt.Sprintf("%-26s %-14s %-14s %s", limit.Resource, formatLimit(limit.Soft), formatLimit(limit.Hard), limit.Unit)
+			fmt.Println(valueStyle.Render(row))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render(fmt.Sprintf("FILE DESCRIPTORS (%d)", detail.FDCount)))
+	for _, fd := range detail.FDs {
+		fmt.Println(valueStyle.Render(fmt.Sprintf("%-6d %s", fd.FD, fd.Target)))
+	}
+
+	if len(detail.Environ) > 0 {
+		fmt.Println()
+		fmt.Println(titleStyle.Render("ENVIRONMENT"))
+		for _, env := range detail.Environ {
+			fmt.Println(valueStyle.Render(env))
+		}
+	}
+}
+
+func formatLimit(v int64) string {
+	if v < 0 {
+		return "unlimited"
+	}
+	return strconv.FormatInt(v, 10)
+}
+
 // Helper functions
 
 func printTable(rows [][]string) {
@@ -496,11 +796,11 @@ func printTable(rows [][]string) {
 }
 
 func formatBytes(bytes uint64) string {
-	const unit = 1024
+	unit := uint64(units.ByteUnit())
 	if bytes < unit {
 		return fmt.Sprintf("%d B", bytes)
 	}
-	div, exp := int64(unit), 0
+	div, exp := unit, 0
 	for n := bytes / unit; n >= unit; n /= unit {
 		div *= unit
 		exp++
@@ -508,6 +808,12 @@ func formatBytes(bytes uint64) string {
 	return fmt.Sprintf("%.2f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
+// formatTemperature shows celsius in the configured temperature unit
+func formatTemperature(format string, celsius float64) string {
+	temp, unit := units.ConvertTemperature(celsius)
+	return fmt.Sprintf(format, temp) + unit
+}
+
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -554,7 +860,48 @@ func displayGPUInfo(gpuInfo *models.GPUInfo) {
 			{"Name:", gpu.DisplayName},
 			{"Full Name:", gpu.FullName},
 			{"PCI ID:", gpu.PciId},
-			{"Temperature:", fmt.Sprintf("%.1f°C", gpu.Temperature)},
+			{"Temperature:", formatTemperature("%.1f", gpu.Temperature)},
+		}
+
+		printTable(rows)
+	}
+}
+
+func displayGPUUsage(gpuUsage *models.GPUUsageInfo) {
+	fmt.Println(titleStyle.Render("GPU USAGE"))
+
+	if len(gpuUsage.GPUs) == 0 {
+		fmt.Println(valueStyle.Render("  No GPUs detected"))
+		return
+	}
+
+	for i, gpu := range gpuUsage.GPUs {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		fmt.Println(keyStyle.Render(fmt.Sprintf("%s (%s):", gpu.BDF, gpu.Driver)))
+
+		rows := [][]string{}
+		if gpu.Driver == "amdgpu" || gpu.Driver == "nvidia" {
+			rows = append(rows, []string{"Utilization:", fmt.Sprintf("%.0f%% (memory %.0f%%)", gpu.Utilization, gpu.MemoryUtilization)})
+		}
+		if gpu.VRAMTotal > 0 {
+			rows = append(rows, []string{"VRAM:", fmt.Sprintf("%s / %s", formatBytes(gpu.VRAMUsed), formatBytes(gpu.VRAMTotal))})
+		}
+		if gpu.CoreClockMax > 0 {
+			rows = append(rows, []string{"Core Clock:", fmt.Sprintf("%d / %d MHz", gpu.CoreClock, gpu.CoreClockMax)})
+		}
+		if gpu.MemoryClockMax > 0 {
+			rows = append(rows, []string{"Memory Clock:", fmt.Sprintf("%d / %d MHz", gpu.MemoryClock, gpu.MemoryClockMax)})
+		}
+		if gpu.PowerCap > 0 {
+			rows = append(rows, []string{"Power:", fmt.Sprintf("%.1f / %.0f W", gpu.Power, gpu.PowerCap)})
+		} else if gpu.Power > 0 {
+			rows = append(rows, []string{"Power:", fmt.Sprintf("%.1f W", gpu.Power)})
+		}
+		if len(rows) == 0 {
+			rows = append(rows, []string{"Usage:", "not reported by driver"})
 		}
 
 		printTable(rows)
@@ -590,6 +937,26 @@ func displayMetaInfo(meta *models.MetaInfo) {
 		fmt.Println()
 	}
 
+	if meta.GPUUsage != nil {
+		displayGPUUsage(meta.GPUUsage)
+		fmt.Println()
+	}
+
+	if meta.Power != nil {
+		displayPowerInfo(meta.Power)
+		fmt.Println()
+	}
+
+	if len(meta.Sensors) > 0 {
+		displaySensors(meta.Sensors)
+		fmt.Println()
+	}
+
+	if len(meta.Temperatures) > 0 {
+		displayTemperatures(meta.Temperatures)
+		fmt.Println()
+	}
+
 	if len(meta.Network) > 0 {
 		displayNetworkInfo(meta.Network)
 		fmt.Println()
@@ -605,11 +972,21 @@ func displayMetaInfo(meta *models.MetaInfo) {
 		fmt.Println()
 	}
 
+	if len(meta.BlockDevices) > 0 {
+		displayBlockDevices(meta.BlockDevices)
+		fmt.Println()
+	}
+
 	if meta.DiskRate != nil {
 		displayDiskRates(meta.DiskRate)
 		fmt.Println()
 	}
 
+	if len(meta.Connections) > 0 {
+		displayConnections(meta.Connections)
+		fmt.Println()
+	}
+
 	if len(meta.Processes) > 0 {
 		displayProcesses(meta.Processes)
 	}
@@ -633,7 +1010,7 @@ func displayGPUTempInfo(gpuTemp *models.GPUTempInfo) {
 	rows := [][]string{
 		{"Driver:", gpuTemp.Driver},
 		{"Hwmon:", gpuTemp.Hwmon},
-		{"Temperature:", fmt.Sprintf("%.1f°C", gpuTemp.Temperature)},
+		{"Temperature:", formatTemperature("%.1f", gpuTemp.Temperature)},
 	}
 
 	printTable(rows)
@@ -660,6 +1037,15 @@ func displayNetworkRates(netRates *models.NetworkRateResponse) {
 			{"RX Total:", formatBytes(iface.RxTotal)},
 			{"TX Total:", formatBytes(iface.TxTotal)},
 		}
+		if iface.RxPacketRate > 0 || iface.TxPacketRate > 0 {
+			rows = append(rows, []string{"Packets:", fmt.Sprintf("%.1f rx/s, %.1f tx/s", iface.RxPacketRate, iface.TxPacketRate)})
+		}
+		if iface.RxErrorRate > 0 || iface.TxErrorRate > 0 || iface.RxDropRate > 0 || iface.TxDropRate > 0 {
+			rows = append(rows,
+				[]string{"Errors:", fmt.Sprintf("%.1f rx/s, %.1f tx/s", iface.RxErrorRate, iface.TxErrorRate)},
+				[]string{"Drops:", fmt.Sprintf("%.1f rx/s, %.1f tx/s", iface.RxDropRate, iface.TxDropRate)},
+			)
+		}
 
 		printTable(rows)
 	}
@@ -690,6 +1076,14 @@ func displayDiskRates(diskRates *models.DiskRateResponse) {
 			{"Read Count:", fmt.Sprintf("%d", disk.ReadCount)},
 			{"Write Count:", fmt.Sprintf("%d", disk.WriteCount)},
 		}
+		if disk.ReadIOPS > 0 || disk.WriteIOPS > 0 || disk.Util > 0 {
+			rows = append(rows,
+				[]string{"IOPS:", fmt.Sprintf("%.1f r/s, %.1f w/s", disk.ReadIOPS, disk.WriteIOPS)},
+				[]string{"Await:", fmt.Sprintf("%.2f ms read, %.2f ms write", disk.ReadAwait, disk.WriteAwait)},
+				[]string{"Utilization:", fmt.Sprintf("%.1f%%", disk.Util)},
+				[]string{"Queue Size:", fmt.Sprintf("%.2f", disk.QueueSize)},
+			)
+		}
 
 		printTable(rows)
 	}
@@ -702,11 +1096,11 @@ func formatRate(bytesPerSecond float64) string {
 }
 
 func formatBytesFloat(bytes float64) string {
-	const unit = 1024
+	unit := units.ByteUnit()
 	if bytes < unit {
 		return fmt.Sprintf("%.2f B", bytes)
 	}
-	div, exp := float64(unit), 0
+	div, exp := unit, 0
 	for n := bytes / unit; n >= unit; n /= unit {
 		div *= unit
 		exp++
@@ -715,7 +1109,7 @@ func formatBytesFloat(bytes float64) string {
 }
 
 func runNetRateCommand(gopsUtil *gops.GopsUtil) error {
-	netRateInfo, err := gopsUtil.GetNetworkRates(netRateCursor)
+	netRateInfo, err := gopsUtil.GetNetworkRatesFiltered(netRateCursor, netFilterFromFlags())
 	if err != nil {
 		return fmt.Errorf("failed to get network rates: %w", err)
 	}
@@ -728,8 +1122,22 @@ func runNetRateCommand(gopsUtil *gops.GopsUtil) error {
 	return nil
 }
 
+func runPowerCommand(gopsUtil *gops.GopsUtil) error {
+	powerInfo, err := gopsUtil.GetPowerInfo(powerCursor)
+	if err != nil {
+		return fmt.Errorf("failed to get power info: %w", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(powerInfo)
+	}
+
+	displayPowerInfo(powerInfo)
+	return nil
+}
+
 func runDiskRateCommand(gopsUtil *gops.GopsUtil) error {
-	diskRateInfo, err := gopsUtil.GetDiskRates(diskRateCursor)
+	diskRateInfo, err := gopsUtil.GetDiskRatesFiltered(diskRateCursor, diskFilterFromFlags())
 	if err != nil {
 		return fmt.Errorf("failed to get disk rates: %w", err)
 	}
@@ -743,5 +1151,5 @@ func runDiskRateCommand(gopsUtil *gops.GopsUtil) error {
 }
 
 func runTopCommand(gopsUtil *gops.GopsUtil) error {
-	return runTUIWithOptions(gopsUtil, hideCPUCores, summarizeCores)
+	return runTUIWithOptions(gopsUtil, tuiOptions())
 }
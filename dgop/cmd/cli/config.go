@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+	Long:  "Inspect the configuration read from ~/.config/dgop/config.json and environment variables.",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration",
+	Long:  "Print the configuration after merging defaults, the config file and environment variables. The output is valid config.json, except that API keys are redacted.",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigShowCommand,
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+}
+
+func runConfigShowCommand(cmd *cobra.Command, args []string) error {
+	// Keep API keys out of terminals and logs
+	cfg := *appConfig
+	cfg.Server.APIKeys = make([]string, len(appConfig.Server.APIKeys))
+	for i := range cfg.Server.APIKeys {
+		cfg.Server.APIKeys[i] = "REDACTED"
+	}
+
+	if jsonOutput {
+		return outputJSON(cfg)
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/AvengeMedia/dgop/gops"
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/spf13/cobra"
+)
+
+var connectionsCmd = &cobra.Command{
+	Use:   "connections",
+	Short: "Get open sockets",
+	Long:  "Display tcp, udp and unix sockets with their state and owning process.",
+}
+
+var portsCmd = &cobra.Command{
+	Use:   "ports",
+	Short: "Get listening ports",
+	Long:  "Display the tcp and udp ports that are open for incoming connections and which process owns them.",
+}
+
+func runConnectionsCommand(gopsUtil *gops.GopsUtil) error {
+	conns, err := gopsUtil.GetConnections(connProtocols, connListening)
+	if err != nil {
+		return fmt.Errorf("failed to get connections: %w", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(conns)
+	}
+
+	displayConnections(conns)
+	return nil
+}
+
+func runPortsCommand(gopsUtil *gops.GopsUtil) error {
+	conns, err := gopsUtil.GetConnections([]string{"tcp", "tcp6", "udp", "udp6"}, true)
+	if err != nil {
+		return fmt.Errorf("failed to get listening ports: %w", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(conns)
+	}
+
+	displayPorts(conns)
+	return nil
+}
+
+func displayConnections(conns []*models.ConnectionInfo) {
+	fmt.Println(titleStyle.Render(fmt.Sprintf("CONNECTIONS (%d)", len(conns))))
+
+	header := fmt.Sprintf("%-6s %-30s %-30s %-12s %-8s %s",
+		"PROTO", "LOCAL", "REMOTE", "STATE", "PID", "COMMAND")
+	fmt.Println(keyStyle.Render(header))
+	fmt.Println(strings.Repeat("─", 100))
+
+	for _, conn := range conns {
+		local := formatEndpoint(conn.LocalAddr, conn.LocalPort)
+		remote := formatEndpoint(conn.RemoteAddr, conn.RemotePort)
+		if conn.Protocol == "unix" {
+			local, remote = conn.LocalAddr, ""
+			if local == "" {
+				local = "-"
+			}
+		}
+
+		row := fmt.Sprintf("%-6s %-30s %-30s %-12s %-8s %s",
+			conn.Protocol,
+			truncateString(local, 30),
+			truncateString(remote, 30),
+			conn.State,
+			formatOwnerPID(conn.PID),
+			conn.Command)
+		fmt.Println(valueStyle.Render(row))
+	}
+}
+
+func displayPorts(conns []*models.ConnectionInfo) {
+	fmt.Println(titleStyle.Render(fmt.Sprintf("LISTENING PORTS (%d)", len(conns))))
+
+	header := fmt.Sprintf("%-6s %-30s %-10s %-8s %s",
+		"PROTO", "ADDRESS", "SCOPE", "PID", "COMMAND")
+	fmt.Println(keyStyle.Render(header))
+	fmt.Println(strings.Repeat("─", 80))
+
+	for _, conn := range conns {
+		row := fmt.Sprintf("%-6s %-30s %-10s %-8s %s",
+			conn.Protocol,
+			truncateString(formatEndpoint(conn.LocalAddr, conn.LocalPort), 30),
+			listenScope(conn.LocalAddr),
+			formatOwnerPID(conn.PID),
+			conn.Command)
+		fmt.Println(valueStyle.Render(row))
+	}
+}
+
+func formatEndpoint(addr string, port uint16) string {
+	ip := net.ParseIP(addr)
+	if ip != nil && ip.IsUnspecified() && port == 0 {
+		return "*"
+	}
+	return net.JoinHostPort(addr, strconv.Itoa(int(port)))
+}
+
+// listenScope says who can reach a listening socket
+func listenScope(addr string) string {
+	ip := net.ParseIP(addr)
+	switch {
+	case ip == nil:
+		return ""
+	case ip.IsUnspecified():
+		return "all"
+	case ip.IsLoopback():
+		return "loopback"
+	default:
+		return "address"
+	}
+}
+
+func formatOwnerPID(pid int32) string {
+	if pid == 0 {
+		return "-"
+	}
+	return strconv.Itoa(int(pid))
+}
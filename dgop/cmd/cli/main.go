@@ -4,11 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/AvengeMedia/dgop/cmd/cli/tui"
+	"github.com/AvengeMedia/dgop/config"
 	"github.com/AvengeMedia/dgop/gops"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -26,6 +30,43 @@ var (
 	diskRateCursor string
 	hideCPUCores   bool
 	summarizeCores bool
+	socketPath     string
+	noTCP          bool
+	procTree       bool
+	killSignal     string
+	reniceNice     int
+	matchFull      bool
+	dryRun         bool
+	showEnv        bool
+	sample         bool
+	sampleInterval time.Duration
+	procInterval   time.Duration
+	procUsers      []string
+	procCommand    string
+	procCmdline    string
+	procPIDs       []int32
+	procMinCPU     float64
+	procMinMemory  float32
+	hideKernel     bool
+	onlyMine       bool
+	connProtocols  []string
+	connListening  bool
+	powerCursor    string
+	skipFSTypes    []string
+	blockSMART     bool
+	netInclude     []string
+	netExclude     []string
+	diskInclude    []string
+	diskExclude    []string
+	listenAddr     string
+	allowedClients []string
+	corsOrigins    []string
+	allowActions   bool
+	topTree        bool
+
+	// Loaded in main, flag defaults come from it
+	appConfig *config.Config
+	units     config.Units
 )
 
 var style = lipgloss.NewStyle().
@@ -68,7 +109,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
 	rootCmd.PersistentFlags().BoolVar(&disableProcCPU, "no-cpu", false, "Disable CPU calculation for faster process listing")
 
-	allCmd.Flags().StringVar(&procSortBy, "sort", "cpu", "Sort processes by (cpu, memory, name, pid)")
+	allCmd.Flags().StringVar(&procSortBy, "sort", "cpu", "Sort processes by (cpu, memory, name, pid, io)")
 	allCmd.Flags().IntVar(&procLimit, "limit", 0, "Limit number of processes (0 = no limit)")
 	allCmd.Flags().StringVar(&cpuCursor, "cpu-cursor", "", "CPU cursor from previous request")
 	allCmd.Flags().StringVar(&procCursor, "proc-cursor", "", "Process cursor from previous request")
@@ -76,39 +117,144 @@ func init() {
 	cpuCmd.Flags().StringVar(&cpuCursor, "cursor", "", "Cursor from previous CPU request")
 
 	netRateCmd.Flags().StringVar(&netRateCursor, "cursor", "", "Cursor from previous network rate request")
+	addNetFilterFlags(netRateCmd)
+	addNetFilterFlags(networkCmd)
 
 	diskRateCmd.Flags().StringVar(&diskRateCursor, "cursor", "", "Cursor from previous disk rate request")
+	addDiskFilterFlags(diskRateCmd)
+	addDiskFilterFlags(diskCmd)
 
-	processesCmd.Flags().StringVar(&procSortBy, "sort", "cpu", "Sort processes by (cpu, memory, name, pid)")
+	powerCmd.Flags().StringVar(&powerCursor, "cursor", "", "Cursor from previous power request, for battery time estimates")
+
+	processesCmd.Flags().StringVar(&procSortBy, "sort", "cpu", "Sort processes by (cpu, memory, name, pid, io)")
 	processesCmd.Flags().IntVar(&procLimit, "limit", 0, "Limit number of processes (0 = no limit)")
 	processesCmd.Flags().StringVar(&procCursor, "cursor", "", "Cursor from previous process request")
+	processesCmd.Flags().BoolVar(&procTree, "tree", false, "Show processes as a tree with per-subtree CPU and memory totals")
+	addProcessFilterFlags(processesCmd)
 
 	metaCmd.Flags().StringSliceVar(&metaModules, "modules", []string{"all"}, "Modules to include (cpu,memory,network,etc)")
-	metaCmd.Flags().StringVar(&procSortBy, "sort", "cpu", "Sort processes by (cpu, memory, name, pid)")
+	metaCmd.Flags().StringVar(&procSortBy, "sort", "cpu", "Sort processes by (cpu, memory, name, pid, io)")
 	metaCmd.Flags().IntVar(&procLimit, "limit", 0, "Limit number of processes (0 = no limit)")
 	metaCmd.Flags().StringSliceVar(&metaGPUPciIds, "gpu-pci-ids", []string{}, "PCI IDs for GPU temperatures (e.g., 10de:2684,1002:164e)")
 	metaCmd.Flags().StringVar(&cpuCursor, "cpu-cursor", "", "CPU cursor from previous request")
 	metaCmd.Flags().StringVar(&procCursor, "proc-cursor", "", "Process cursor from previous request")
 	metaCmd.Flags().StringVar(&netRateCursor, "net-rate-cursor", "", "Network rate cursor from previous request")
 	metaCmd.Flags().StringVar(&diskRateCursor, "disk-rate-cursor", "", "Disk rate cursor from previous request")
+	metaCmd.Flags().StringVar(&powerCursor, "power-cursor", "", "Power cursor from previous request")
+	metaCmd.Flags().StringSliceVar(&skipFSTypes, "skip-fstypes", []string{}, "Filesystem types to leave out of diskmounts (default tmpfs,devtmpfs, none keeps all)")
+	blockDevicesCmd.Flags().BoolVar(&blockSMART, "smart", false, "Include SMART health (needs smartctl, usually root)")
+	diskCmd.Flags().StringSliceVar(&skipFSTypes, "skip-fstypes", []string{}, "Filesystem types to leave out (default tmpfs,devtmpfs, none keeps all)")
+	metaCmd.Flags().BoolVar(&blockSMART, "smart", false, "Include SMART health in blockdevices (needs smartctl)")
+	addProcessFilterFlags(metaCmd)
+	addNetFilterFlags(metaCmd)
+	addDiskFilterFlags(metaCmd)
+	metaCmd.Flags().BoolVar(&connListening, "listening", false, "Only listening sockets (when connections module is requested)")
 
 	gpuTempCmd.Flags().StringVar(&gpuPciId, "pci-id", "", "PCI ID of GPU to get temperature (e.g., 10de:2684)")
 	gpuTempCmd.MarkFlagRequired("pci-id")
 
+	serverCmd.Flags().StringVar(&listenAddr, "listen", "", "TCP address to listen on, host:port or :port for every address")
+	serverCmd.Flags().StringSliceVar(&allowedClients, "allowed-clients", []string{}, "Only accept TCP clients from these CIDRs or addresses")
+	serverCmd.Flags().StringSliceVar(&corsOrigins, "cors-origins", []string{}, "Origins browser dashboards may call the API from (* for any)")
+	serverCmd.Flags().BoolVar(&allowActions, "allow-process-actions", false, "Serve the signal and renice endpoints without api_keys")
+	serverCmd.Flags().StringVar(&socketPath, "unix-socket", "", "Serve JSON-RPC on a unix socket (default path "+config.DefaultSocketPath()+")")
+	serverCmd.Flags().Lookup("unix-socket").NoOptDefVal = config.DefaultSocketPath()
+	serverCmd.Flags().BoolVar(&noTCP, "no-tcp", false, "Don't listen on TCP, only on the unix socket")
+	serverCmd.Flags().BoolVar(&sample, "sample", false, "Collect cpu, process and rate metrics in the background so requests need no cursors")
+	serverCmd.Flags().DurationVar(&sampleInterval, "sample-interval", gops.DefaultSampleInterval, "Background sampling interval for cpu, net-rate and disk-rate")
+	serverCmd.Flags().DurationVar(&procInterval, "proc-sample-interval", gops.DefaultProcSampleInterval, "Background sampling interval for processes")
+
+	processCmd.Flags().BoolVar(&showEnv, "env", false, "Include the environment variables")
+
+	connectionsCmd.Flags().StringSliceVar(&connProtocols, "protocols", []string{}, "Protocols to include (tcp,tcp6,udp,udp6,unix)")
+	connectionsCmd.Flags().BoolVar(&connListening, "listening", false, "Only listening sockets")
+
+	killCmd.Flags().StringVarP(&killSignal, "signal", "s", "TERM", "Signal name or number")
+	killCmd.Flags().BoolVarP(&matchFull, "full", "f", false, "Match the pattern against the full command line")
+	killCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only list the processes that would be signalled")
+	reniceCmd.Flags().IntVarP(&reniceNice, "nice", "n", 0, "Nice value, -20 (highest priority) to 19")
+	reniceCmd.MarkFlagRequired("nice")
+	reniceCmd.Flags().BoolVarP(&matchFull, "full", "f", false, "Match the pattern against the full command line")
+	reniceCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only list the processes that would be reniced")
+
 	topCmd.Flags().BoolVar(&hideCPUCores, "hide-cpu-cores", false, "Hide individual CPU core display in TUI")
 	topCmd.Flags().BoolVar(&summarizeCores, "summarize-cores", false, "Show summarized CPU core groups instead of individual cores")
+	topCmd.Flags().StringVar(&procSortBy, "sort", "cpu", "Sort processes by (cpu, memory, name, pid, io)")
+	topCmd.Flags().BoolVar(&topTree, "tree", false, "Start in process tree view")
+}
+
+// applyConfig makes the config file and environment the flag defaults, so
+// flags given on the command line still win
+func applyConfig(cfg *config.Config) {
+	appConfig = cfg
+	units = cfg.Units
+
+	procSortBy = cfg.Processes.Sort
+	procLimit = cfg.Processes.Limit
+	metaModules = cfg.Modules
+
+	hideCPUCores = cfg.TUI.HideCPUCores
+	summarizeCores = cfg.TUI.SummarizeCores
+	topTree = cfg.TUI.ProcessTree
+
+	listenAddr = cfg.Server.Listen
+	allowedClients = cfg.Server.AllowedClients
+	corsOrigins = cfg.Server.CORS.AllowedOrigins
+	allowActions = cfg.Server.AllowProcessActions
+	socketPath = cfg.Server.SocketPath
+	noTCP = cfg.Server.DisableTCP
+	sample = cfg.Server.Sampler
+	sampleInterval = cfg.Server.SampleInterval.Duration
+	procInterval = cfg.Server.ProcSampleInterval.Duration
+
+	// Show the configured values as defaults in --help
+	for _, cmd := range []*cobra.Command{allCmd, processesCmd, metaCmd, topCmd, serverCmd} {
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			f.DefValue = f.Value.String()
+		})
+	}
+}
+
+func addProcessFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&procUsers, "user", []string{}, "Only processes owned by these users")
+	cmd.Flags().StringVar(&procCommand, "command", "", "Only processes whose name matches this regular expression")
+	cmd.Flags().StringVar(&procCmdline, "cmdline", "", "Only processes whose full command line matches this regular expression")
+	cmd.Flags().Int32SliceVar(&procPIDs, "pids", []int32{}, "Only these PIDs")
+	cmd.Flags().Float64Var(&procMinCPU, "min-cpu", 0, "Minimum CPU percent")
+	cmd.Flags().Float32Var(&procMinMemory, "min-memory", 0, "Minimum memory percent")
+	cmd.Flags().BoolVar(&hideKernel, "hide-kernel", false, "Hide kernel threads")
+	cmd.Flags().BoolVar(&onlyMine, "mine", false, "Only your own processes")
+}
+
+// Device filter flags layer over the configured filters, an include list
+// replaces them while an exclude list only replaces the configured exclude list
+func addNetFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&netInclude, "net-include", []string{}, "Only interfaces matching these globs (e.g. wl*,en*)")
+	cmd.Flags().StringSliceVar(&netExclude, "net-exclude", []string{}, "Leave out interfaces matching these globs (default lo,veth*,ifb*,dummy*, ...)")
+}
+
+func addDiskFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&diskInclude, "disk-include", []string{}, "Only disks matching these globs (default sd*,nvme*,vd*,xvd*,hd*,mmcblk*,dm-*,md*,sr*)")
+	cmd.Flags().StringSliceVar(&diskExclude, "disk-exclude", []string{}, "Leave out disks matching these globs")
 }
 
 var rootCmd = &cobra.Command{
 	Use: "dankgop",
-	Run: func(cmd *cobra.Command, args []string) {
-		gopsUtil := gops.NewGopsUtil()
-		runTUIWithOptions(gopsUtil, hideCPUCores, summarizeCores)
-	},
 }
 
 func main() {
-	gopsUtil := gops.NewGopsUtil()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	applyConfig(cfg)
+
+	gopsUtil, err := newGopsUtil(cfg)
+	if err != nil {
+		log.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
 
 	// Set the gopsUtil in context for commands
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
@@ -131,19 +277,34 @@ func setupCommands(gopsUtil *gops.GopsUtil) {
 	rootCmd.AddCommand(memoryCmd)
 	rootCmd.AddCommand(networkCmd)
 	rootCmd.AddCommand(diskCmd)
+	rootCmd.AddCommand(blockDevicesCmd)
 	rootCmd.AddCommand(processesCmd)
 	rootCmd.AddCommand(systemCmd)
 	rootCmd.AddCommand(hardwareCmd)
 	rootCmd.AddCommand(gpuCmd)
 	rootCmd.AddCommand(gpuTempCmd)
+	rootCmd.AddCommand(gpuUsageCmd)
 	rootCmd.AddCommand(metaCmd)
 	rootCmd.AddCommand(modulesCmd)
 	rootCmd.AddCommand(netRateCmd)
 	rootCmd.AddCommand(diskRateCmd)
+	rootCmd.AddCommand(powerCmd)
+	rootCmd.AddCommand(sensorsCmd)
+	rootCmd.AddCommand(tempsCmd)
 	rootCmd.AddCommand(topCmd)
 	rootCmd.AddCommand(serverCmd)
+	rootCmd.AddCommand(processCmd)
+	rootCmd.AddCommand(killCmd)
+	rootCmd.AddCommand(reniceCmd)
+	rootCmd.AddCommand(connectionsCmd)
+	rootCmd.AddCommand(portsCmd)
+	rootCmd.AddCommand(configCmd)
 
 	// Set gopsUtil for all commands
+	rootCmd.Run = func(cmd *cobra.Command, args []string) {
+		runTUIWithOptions(gopsUtil, tuiOptions())
+	}
+
 	allCmd.RunE = func(cmd *cobra.Command, args []string) error {
 		return runAllCommand(gopsUtil)
 	}
@@ -168,14 +329,50 @@ func setupCommands(gopsUtil *gops.GopsUtil) {
 		return runDiskRateCommand(gopsUtil)
 	}
 
+	powerCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runPowerCommand(gopsUtil)
+	}
+
+	sensorsCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runSensorsCommand(gopsUtil)
+	}
+
+	tempsCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runTempsCommand(gopsUtil)
+	}
+
 	diskCmd.RunE = func(cmd *cobra.Command, args []string) error {
 		return runDiskCommand(gopsUtil)
 	}
 
+	blockDevicesCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runBlockDevicesCommand(gopsUtil)
+	}
+
 	processesCmd.RunE = func(cmd *cobra.Command, args []string) error {
 		return runProcessesCommand(gopsUtil)
 	}
 
+	processCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runProcessCommand(gopsUtil, args[0])
+	}
+
+	killCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runKillCommand(gopsUtil, args[0])
+	}
+
+	reniceCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runReniceCommand(gopsUtil, reniceNice, args[0])
+	}
+
+	connectionsCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runConnectionsCommand(gopsUtil)
+	}
+
+	portsCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runPortsCommand(gopsUtil)
+	}
+
 	systemCmd.RunE = func(cmd *cobra.Command, args []string) error {
 		return runSystemCommand(gopsUtil)
 	}
@@ -192,6 +389,10 @@ func setupCommands(gopsUtil *gops.GopsUtil) {
 		return runGPUTempCommand(gopsUtil)
 	}
 
+	gpuUsageCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runGPUUsageCommand(gopsUtil)
+	}
+
 	metaCmd.RunE = func(cmd *cobra.Command, args []string) error {
 		return runMetaCommand(gopsUtil)
 	}
@@ -218,6 +419,51 @@ func outputJSON(data any) error {
 	return nil
 }
 
+// newGopsUtil applies the device filters from the config file and environment
+func newGopsUtil(cfg *config.Config) (*gops.GopsUtil, error) {
+	gopsUtil := gops.NewGopsUtil()
+	err := gopsUtil.SetDeviceFilters(gops.DeviceFilters{
+		Interfaces: gops.DeviceFilter{Include: cfg.Filters.NetInclude, Exclude: cfg.Filters.NetExclude},
+		Disks:      gops.DeviceFilter{Include: cfg.Filters.DiskInclude, Exclude: cfg.Filters.DiskExclude},
+	})
+	return gopsUtil, err
+}
+
+func tuiOptions() tui.Options {
+	return tui.Options{
+		HideCPUCores:   hideCPUCores,
+		SummarizeCores: summarizeCores,
+		TreeView:       topTree,
+		SortBy:         parseProcessSortBy(procSortBy, false),
+		ProcLimit:      procLimit,
+		Refresh:        appConfig.TUI.Refresh.Duration,
+		RateRefresh:    appConfig.TUI.RateRefresh.Duration,
+		SensorRefresh:  appConfig.TUI.SensorRefresh.Duration,
+		Units:          units,
+	}
+}
+
+func netFilterFromFlags() gops.DeviceFilter {
+	return gops.DeviceFilter{Include: netInclude, Exclude: netExclude}
+}
+
+func diskFilterFromFlags() gops.DeviceFilter {
+	return gops.DeviceFilter{Include: diskInclude, Exclude: diskExclude}
+}
+
+func processFilterFromFlags() gops.ProcessFilter {
+	return gops.ProcessFilter{
+		Usernames:         procUsers,
+		Command:           procCommand,
+		Cmdline:           procCmdline,
+		PIDs:              procPIDs,
+		MinCPU:            procMinCPU,
+		MinMemory:         procMinMemory,
+		HideKernelThreads: hideKernel,
+		OnlyMine:          onlyMine,
+	}
+}
+
 func parseProcessSortBy(sortBy string, cpuDisabled bool) gops.ProcSortBy {
 	// If CPU is disabled and user chose CPU sort, default to memory
 	if cpuDisabled && sortBy == "cpu" {
@@ -233,6 +479,8 @@ func parseProcessSortBy(sortBy string, cpuDisabled bool) gops.ProcSortBy {
 		return gops.SortByName
 	case "pid":
 		return gops.SortByPID
+	case "io":
+		return gops.SortByIO
 	default:
 		// Default behavior: CPU if enabled, memory if CPU disabled
 		if cpuDisabled {
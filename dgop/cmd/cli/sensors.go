@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AvengeMedia/dgop/gops"
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/spf13/cobra"
+)
+
+var sensorsCmd = &cobra.Command{
+	Use:   "sensors",
+	Short: "Get hardware sensor readings",
+	Long:  "Display temperature, fan, voltage, power and current readings of every hwmon chip with their thresholds and alarms.",
+}
+
+var tempsCmd = &cobra.Command{
+	Use:     "temps",
+	Aliases: []string{"temperatures"},
+	Short:   "Get temperatures",
+	Long:    "Display temperature sensors grouped by chip, with their high and critical thresholds.",
+}
+
+func runSensorsCommand(gopsUtil *gops.GopsUtil) error {
+	chips, err := gopsUtil.GetSensors()
+	if err != nil {
+		return fmt.Errorf("failed to get sensors: %w", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(chips)
+	}
+
+	displaySensors(chips)
+	return nil
+}
+
+func displaySensors(chips []*models.SensorChip) {
+	fmt.Println(titleStyle.Render("SENSORS"))
+
+	if len(chips) == 0 {
+		fmt.Println(valueStyle.Render("  No sensors found"))
+		return
+	}
+
+	for i, chip := range chips {
+		if i > 0 {
+			fmt.Println()
+		}
+		name := chip.Name
+		if chip.Device != "" {
+			name = fmt.Sprintf("%s (%s)", name, chip.Device)
+		}
+		fmt.Println(keyStyle.Render(name))
+
+		for _, reading := range chip.Readings {
+			line := fmt.Sprintf("  %-20s %12s", truncateString(reading.Label, 20), formatSensorValue(reading, reading.Value))
+			if limits := formatSensorLimits(reading); limits != "" {
+				line += "  (" + limits + ")"
+			}
+			if len(reading.Alarms) > 0 {
+				line += "  ALARM: " + strings.Join(reading.Alarms, ",")
+			}
+			fmt.Println(valueStyle.Render(line))
+		}
+	}
+}
+
+func runTempsCommand(gopsUtil *gops.GopsUtil) error {
+	chips, err := gopsUtil.GetTemperatures()
+	if err != nil {
+		return fmt.Errorf("failed to get temperatures: %w", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(chips)
+	}
+
+	displayTemperatures(chips)
+	return nil
+}
+
+func displayTemperatures(chips []*models.TemperatureChip) {
+	fmt.Println(titleStyle.Render("TEMPERATURES"))
+
+	if len(chips) == 0 {
+		fmt.Println(valueStyle.Render("  No temperature sensors found"))
+		return
+	}
+
+	for i, chip := range chips {
+		if i > 0 {
+			fmt.Println()
+		}
+		name := chip.Name
+		if chip.Device != "" {
+			name = fmt.Sprintf("%s (%s)", name, chip.Device)
+		}
+		fmt.Println(keyStyle.Render(name))
+
+		for _, sensor := range chip.Sensors {
+			line := fmt.Sprintf("  %-20s %10s", truncateString(sensor.Label, 20), formatTemperature("%.1f", sensor.Temperature))
+			var limits []string
+			if sensor.High > 0 {
+				limits = append(limits, "high "+formatTemperature("%.1f", sensor.High))
+			}
+			if sensor.Critical > 0 {
+				limits = append(limits, "crit "+formatTemperature("%.1f", sensor.Critical))
+			}
+			if len(limits) > 0 {
+				line += "  (" + strings.Join(limits, ", ") + ")"
+			}
+			if len(sensor.Alarms) > 0 {
+				line += "  ALARM: " + strings.Join(sensor.Alarms, ",")
+			}
+			fmt.Println(valueStyle.Render(line))
+		}
+	}
+}
+
+func formatSensorValue(reading *models.SensorReading, value float64) string {
+	switch reading.Type {
+	case "fan":
+		return fmt.Sprintf("%.0f %s", value, reading.Unit)
+	case "temp":
+		return formatTemperature("%.1f", value)
+	default:
+		return fmt.Sprintf("%.2f %s", value, reading.Unit)
+	}
+}
+
+func formatSensorLimits(reading *models.SensorReading) string {
+	limits := []struct {
+		name  string
+		value float64
+	}{
+		{"min", reading.Min},
+		{"max", reading.Max},
+		{"lcrit", reading.LowCrit},
+		{"crit", reading.Critical},
+		{"emerg", reading.Emergency},
+	}
+
+	parts := make([]string, 0, len(limits))
+	for _, limit := range limits {
+		if limit.value != 0 {
+			parts = append(parts, fmt.Sprintf("%s %s", limit.name, formatSensorValue(reading, limit.value)))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -2,16 +2,20 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	gops_handler "github.com/AvengeMedia/dgop/api/gops"
 	"github.com/AvengeMedia/dgop/api/middleware"
+	"github.com/AvengeMedia/dgop/api/rpc"
 	"github.com/AvengeMedia/dgop/api/server"
 	"github.com/AvengeMedia/dgop/config"
 	"github.com/AvengeMedia/dgop/errdefs"
@@ -97,8 +101,83 @@ func NewHumaConfig(title, version string) huma.Config {
 }
 
 func runServerCommand(cmd *cobra.Command, args []string) error {
-	cfg := config.NewConfig()
-	return startAPI(cfg)
+	cfg := *appConfig
+	if cmd.Flags().Changed("listen") {
+		cfg.Server.Listen = listenAddr
+	}
+	if cmd.Flags().Changed("allowed-clients") {
+		cfg.Server.AllowedClients = allowedClients
+	}
+	if cmd.Flags().Changed("cors-origins") {
+		cfg.Server.CORS.AllowedOrigins = corsOrigins
+	}
+	if cmd.Flags().Changed("allow-process-actions") {
+		cfg.Server.AllowProcessActions = allowActions
+	}
+	if cmd.Flags().Changed("unix-socket") {
+		cfg.Server.SocketPath = socketPath
+	}
+	if noTCP {
+		cfg.Server.DisableTCP = true
+	}
+	if sample {
+		cfg.Server.Sampler = true
+	}
+	if cmd.Flags().Changed("sample-interval") {
+		cfg.Server.SampleInterval.Duration = sampleInterval
+	}
+	if cmd.Flags().Changed("proc-sample-interval") {
+		cfg.Server.ProcSampleInterval.Duration = procInterval
+	}
+	if cfg.Server.DisableTCP && cfg.Server.SocketPath == "" {
+		return fmt.Errorf("--no-tcp requires --unix-socket")
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	return startAPI(&cfg)
+}
+
+// isLoopback reports whether addr only accepts local connections
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// listenUnixSocket listens on path, replacing a stale socket left behind by a
+// previous run. The socket is only accessible to the current user.
+func listenUnixSocket(path string) (net.Listener, error) {
+	if info, err := os.Stat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%s is already in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(path, 0600); err != nil {
+		l.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+
+	return l, nil
 }
 
 func startAPI(cfg *config.Config) error {
@@ -116,19 +195,46 @@ func startAPI(cfg *config.Config) error {
 	}()
 
 	// Implementation
+	gopsUtil, err := newGopsUtil(cfg)
+	if err != nil {
+		return err
+	}
 	srvImpl := &server.Server{
 		Cfg:  cfg,
-		Gops: gops.NewGopsUtil(),
+		Gops: gopsUtil,
+	}
+
+	if cfg.Server.Sampler {
+		srvImpl.Gops.StartSampler(ctx, gops.SamplerConfig{
+			Interval:     cfg.Server.SampleInterval.Duration,
+			ProcInterval: cfg.Server.ProcSampleInterval.Duration,
+		})
+		log.Infof(" Background sampler: every %s (processes every %s)", cfg.Server.SampleInterval, cfg.Server.ProcSampleInterval)
+	}
+
+	prefixes, err := cfg.Server.ClientPrefixes()
+	if err != nil {
+		return fmt.Errorf("invalid allowed clients: %w", err)
 	}
 
 	// New chi router
 	r := chi.NewRouter()
+	r.Use(middleware.AllowClients(prefixes, cfg.Server.TrustProxyHeaders))
+	r.Use(middleware.AllowHosts(cfg.Server))
+	r.Use(middleware.CORS(cfg.Server.CORS))
 
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
 	})
 
+	r.With(middleware.RequireAPIKey(cfg.Server.APIKeys)).Get("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", gops.PrometheusContentType)
+		if err := srvImpl.Gops.WritePrometheusMetrics(w); err != nil {
+			log.Errorf("Failed to write metrics: %v", err)
+		}
+	})
+
 	r.Group(func(r chi.Router) {
 		r.Use(middleware.Logger)
 
@@ -137,12 +243,19 @@ func startAPI(cfg *config.Config) error {
 
 		config := NewHumaConfig("DankGop API", "1.0.0")
 		config.DocsPath = ""
+		if len(cfg.Server.APIKeys) > 0 {
+			// Lets the docs page send the key
+			config.Components.SecuritySchemes = map[string]*huma.SecurityScheme{
+				"bearer": {Type: "http", Scheme: "bearer"},
+			}
+			config.Security = []map[string][]string{{"bearer": {}}}
+		}
 		api := humachi.New(r, config)
 
 		// Create middleware
 		mw := middleware.NewMiddleware(cfg, api)
 
-		api.UseMiddleware(mw.Recoverer)
+		api.UseMiddleware(mw.Recoverer, mw.Authenticate)
 
 		r.Get("/docs", func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Content-Type", "text/html")
@@ -173,26 +286,56 @@ func startAPI(cfg *config.Config) error {
 		gops_handler.RegisterHandlers(srvImpl, gopsGroup)
 	})
 
-	// Start the server
-	addr := ":63484"
-	log.Infof(" Starting DankGop API server on %s", addr)
-	log.Infof(" API Documentation: http://localhost%s/docs", addr)
-	log.Infof(" OpenAPI Spec: http://localhost%s/openapi.json", addr)
-	log.Infof(" Health Check: http://localhost%s/health", addr)
+	// Serve JSON-RPC on the unix socket
+	if cfg.Server.SocketPath != "" {
+		l, err := listenUnixSocket(cfg.Server.SocketPath)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", cfg.Server.SocketPath, err)
+		}
+		// Closing the listener also removes the socket file
+		defer l.Close()
 
-	h2s := &http2.Server{}
+		rpcServer := rpc.NewServer(srvImpl)
+		log.Infof(" JSON-RPC socket: %s", cfg.Server.SocketPath)
 
-	httpServer := &http.Server{
-		Addr:    addr,
-		Handler: h2c.NewHandler(r, h2s),
+		go func() {
+			if err := rpcServer.Serve(ctx, l); err != nil {
+				log.Fatalf("JSON-RPC server error: %v", err)
+			}
+		}()
 	}
 
-	// Start the server in a goroutine
-	go func() {
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server error: %v", err)
+	var httpServer *http.Server
+	if !cfg.Server.DisableTCP {
+		// Start the server
+		addr := cfg.Server.ListenAddr()
+		baseURL := "http://" + addr
+		if strings.HasPrefix(addr, ":") {
+			baseURL = "http://localhost" + addr
 		}
-	}()
+		log.Infof(" Starting DankGop API server on %s", addr)
+		log.Infof(" API Documentation: %s/docs", baseURL)
+		log.Infof(" OpenAPI Spec: %s/openapi.json", baseURL)
+		log.Infof(" Health Check: %s/health", baseURL)
+		log.Infof(" Prometheus Metrics: %s/metrics", baseURL)
+		if len(cfg.Server.APIKeys) == 0 && !isLoopback(addr) {
+			log.Warnf(" Listening beyond localhost without api_keys, anyone who can reach %s can read process command lines", addr)
+		}
+
+		h2s := &http2.Server{}
+
+		httpServer = &http.Server{
+			Addr:    addr,
+			Handler: h2c.NewHandler(r, h2s),
+		}
+
+		// Start the server in a goroutine
+		go func() {
+			if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Server error: %v", err)
+			}
+		}()
+	}
 
 	// Wait for context cancellation (from signal handler)
 	<-ctx.Done()
@@ -203,8 +346,10 @@ func startAPI(cfg *config.Config) error {
 	defer shutdownCancel()
 
 	// Shutdown the HTTP server
-	if err := httpServer.Shutdown(shutdownCtx); err != nil {
-		log.Fatalf("Server shutdown error: %v", err)
+	if httpServer != nil {
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.Fatalf("Server shutdown error: %v", err)
+		}
 	}
 
 	log.Info("Server gracefully stopped")
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+
+	"github.com/AvengeMedia/dgop/gops"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// processAction is waiting for confirmation in the dialog
+type processAction struct {
+	prompt string
+	done   string
+	run    func() error
+}
+
+type processActionMsg struct {
+	done string
+	err  error
+}
+
+// How long the result of an action stays in the footer
+const actionStatusDuration = 5 * time.Second
+
+func (m *ResponsiveTUIModel) confirmSignal(sig syscall.Signal) {
+	proc := m.getSelectedProcess()
+	if proc == nil {
+		return
+	}
+
+	name := gops.SignalName(sig)
+	m.pendingAction = &processAction{
+		prompt: fmt.Sprintf("Send %s to %d (%s)?", name, proc.PID, proc.Command),
+		done:   fmt.Sprintf("Sent %s to %d", name, proc.PID),
+		run: func() error {
+			return m.gops.SignalProcess(proc.PID, sig)
+		},
+	}
+}
+
+func (m *ResponsiveTUIModel) confirmRenice(delta int) {
+	proc := m.getSelectedProcess()
+	if proc == nil {
+		return
+	}
+
+	current, err := m.gops.GetProcessNice(proc.PID)
+	if err != nil {
+		m.setActionStatus(err.Error())
+		return
+	}
+
+	nice := current + delta
+	if nice < -20 || nice > 19 {
+		return
+	}
+
+	m.pendingAction = &processAction{
+		prompt: fmt.Sprintf("Renice %d (%s) from %d to %d?", proc.PID, proc.Command, current, nice),
+		done:   fmt.Sprintf("Reniced %d to %d", proc.PID, nice),
+		run: func() error {
+			return m.gops.ReniceProcess(proc.PID, nice)
+		},
+	}
+}
+
+// handleConfirmKey handles keys while the dialog is open, everything but
+// confirm, cancel and quit is swallowed
+func (m *ResponsiveTUIModel) handleConfirmKey(msg tea.KeyMsg) tea.Cmd {
+	action := m.pendingAction
+
+	switch msg.String() {
+	case "y", "Y", "enter":
+		m.pendingAction = nil
+		return func() tea.Msg {
+			return processActionMsg{done: action.done, err: action.run()}
+		}
+	case "n", "N", "esc", "q":
+		m.pendingAction = nil
+	case "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
+
+func (m *ResponsiveTUIModel) setActionStatus(status string) {
+	m.actionStatus = status
+	m.actionStatusTime = time.Now()
+}
+
+func (m *ResponsiveTUIModel) renderConfirmDialog() string {
+	colors := m.getColors()
+
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.UI.TextAccent)).
+		Padding(1, 3).
+		Render(m.boldTextStyle().Render(m.pendingAction.prompt) + "\n\n" +
+			m.textStyle().Render("[y]es   [n]o"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, dialog)
+}
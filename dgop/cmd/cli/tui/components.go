@@ -14,11 +14,36 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Options are the dgop top settings from the config file and flags
+type Options struct {
+	HideCPUCores   bool
+	SummarizeCores bool
+	TreeView       bool
+	SortBy         gops.ProcSortBy
+	ProcLimit      int // 0 shows the top 50
+
+	Refresh       time.Duration
+	RateRefresh   time.Duration
+	SensorRefresh time.Duration
+	Units         config.Units
+}
+
+func DefaultOptions() Options {
+	cfg := config.Default()
+	return Options{
+		SortBy:        gops.SortByCPU,
+		Refresh:       cfg.TUI.Refresh.Duration,
+		RateRefresh:   cfg.TUI.RateRefresh.Duration,
+		SensorRefresh: cfg.TUI.SensorRefresh.Duration,
+		Units:         cfg.Units,
+	}
+}
+
 func NewResponsiveTUIModel(gopsUtil *gops.GopsUtil) *ResponsiveTUIModel {
-	return NewResponsiveTUIModelWithOptions(gopsUtil, false, false)
+	return NewResponsiveTUIModelWithOptions(gopsUtil, DefaultOptions())
 }
 
-func NewResponsiveTUIModelWithOptions(gopsUtil *gops.GopsUtil, hideCPUCores, summarizeCores bool) *ResponsiveTUIModel {
+func NewResponsiveTUIModelWithOptions(gopsUtil *gops.GopsUtil, opts Options) *ResponsiveTUIModel {
 	colorManager, err := config.NewColorManager()
 	if err != nil {
 		colorManager = nil
@@ -28,7 +53,8 @@ func NewResponsiveTUIModelWithOptions(gopsUtil *gops.GopsUtil, hideCPUCores, sum
 		{Title: "USER", Width: 4},
 		{Title: "CPU", Width: 3},
 		{Title: "MEMORY", Width: 18},
-		{Title: "COMMAND", Width: 53},
+		{Title: "IO/s", Width: 7},
+		{Title: "COMMAND", Width: 46},
 	}
 
 	t := table.New(
@@ -70,14 +96,22 @@ func NewResponsiveTUIModelWithOptions(gopsUtil *gops.GopsUtil, hideCPUCores, sum
 		gops:           gopsUtil,
 		colorManager:   colorManager,
 		processTable:   t,
-		sortBy:         gops.SortByCPU,
+		sortBy:         opts.SortBy,
 		procLimit:      50,
 		maxNetHistory:  60,
 		maxDiskHistory: 60,
 		selectedPID:    -1,
 		logoTestMode:   false,
-		hideCPUCores:   hideCPUCores,
-		summarizeCores: summarizeCores,
+		hideCPUCores:   opts.HideCPUCores,
+		summarizeCores: opts.SummarizeCores,
+		treeView:       opts.TreeView,
+		refresh:        opts.Refresh,
+		rateRefresh:    opts.RateRefresh,
+		sensorRefresh:  opts.SensorRefresh,
+		units:          opts.Units,
+	}
+	if opts.ProcLimit > 0 {
+		model.procLimit = opts.ProcLimit
 	}
 
 	hardware, _ := gopsUtil.GetSystemHardware()
@@ -393,6 +427,17 @@ func (m *ResponsiveTUIModel) renderSystemInfoPanel(width, height int) string {
 	return style.Render(finalContent)
 }
 
+// tickInterval wakes the update loop often enough for the fastest refresh
+func (m *ResponsiveTUIModel) tickInterval() time.Duration {
+	interval := time.Second
+	for _, d := range []time.Duration{m.refresh, m.rateRefresh, m.sensorRefresh} {
+		if d > 0 && d < interval {
+			interval = d
+		}
+	}
+	return interval
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -435,10 +480,12 @@ func calculateUptime(bootTimeStr string) string {
 }
 
 func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	// Count runes, tree prefixes are multi-byte box drawing characters
+	runes := []rune(s)
+	if len(runes) <= maxLen {
 		return s
 	}
-	return s[:maxLen-3] + "..."
+	return string(runes[:maxLen-3]) + "..."
 }
 
 // getAllDistroLogos returns all available distro logos with their names and colors
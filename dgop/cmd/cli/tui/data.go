@@ -7,8 +7,10 @@ import (
 )
 
 type fetchDataMsg struct {
-	metrics *models.SystemMetrics
-	err     error
+	metrics    *models.SystemMetrics
+	treeRows   []gops.ProcessTreeRow
+	procCursor string
+	err        error
 }
 
 type fetchNetworkMsg struct {
@@ -21,17 +23,26 @@ type fetchDiskMsg struct {
 	err   error
 }
 
-type fetchTempMsg struct {
-	temps []models.TemperatureSensor
+type fetchProcessDetailMsg struct {
+	detail *models.ProcessDetail
+	err    error
+}
+
+type fetchSensorsMsg struct {
+	chips []*models.SensorChip
 	err   error
 }
 
 func (m *ResponsiveTUIModel) fetchData() tea.Cmd {
+	procCursor := m.procCursor
 	return func() tea.Msg {
+		// Fetch every process so the cursor covers them all and CPU and I/O
+		// rates are right for whatever makes the top of the list next time.
+		// The limit is applied below, or when building the tree.
 		params := gops.MetaParams{
-			SortBy:    m.sortBy,
-			ProcLimit: m.procLimit,
-			EnableCPU: true,
+			SortBy:     m.sortBy,
+			EnableCPU:  true,
+			ProcCursor: procCursor,
 		}
 
 		modules := []string{"cpu", "memory", "system", "network", "disk", "processes"}
@@ -58,7 +69,18 @@ func (m *ResponsiveTUIModel) fetchData() tea.Cmd {
 			Processes:  metrics.Processes,
 		}
 
-		return fetchDataMsg{metrics: systemMetrics, err: nil}
+		var treeRows []gops.ProcessTreeRow
+		if m.treeView {
+			treeRows = gops.FlattenProcessTree(gops.BuildProcessTree(metrics.Processes, m.sortBy, m.procLimit))
+			systemMetrics.Processes = make([]*models.ProcessInfo, len(treeRows))
+			for i, row := range treeRows {
+				systemMetrics.Processes[i] = row.Node.ProcessInfo
+			}
+		} else if m.procLimit > 0 && len(systemMetrics.Processes) > m.procLimit {
+			systemMetrics.Processes = systemMetrics.Processes[:m.procLimit]
+		}
+
+		return fetchDataMsg{metrics: systemMetrics, treeRows: treeRows, procCursor: metrics.ProcCursor, err: nil}
 	}
 }
 
@@ -76,9 +98,16 @@ func (m *ResponsiveTUIModel) fetchDiskData() tea.Cmd {
 	}
 }
 
-func (m *ResponsiveTUIModel) fetchTemperatureData() tea.Cmd {
+func (m *ResponsiveTUIModel) fetchSensorData() tea.Cmd {
+	return func() tea.Msg {
+		chips, err := m.gops.GetSensors()
+		return fetchSensorsMsg{chips: chips, err: err}
+	}
+}
+
+func (m *ResponsiveTUIModel) fetchProcessDetail(pid int32) tea.Cmd {
 	return func() tea.Msg {
-		temps, err := m.gops.GetSystemTemperatures()
-		return fetchTempMsg{temps: temps, err: err}
+		detail, err := m.gops.GetProcessDetail(pid, false)
+		return fetchProcessDetailMsg{detail: detail, err: err}
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"github.com/AvengeMedia/dgop/models"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -107,30 +108,23 @@ func (m *ResponsiveTUIModel) renderMemDiskPanel(width, height int) string {
 		}
 
 		// Add sensors if available
-		if len(m.systemTemperatures) > 0 {
+		if sensors := m.sensorPanelReadings(); len(sensors) > 0 {
 			content = append(content, "")
 			content = append(content, m.titleStyle().Render("SENSORS"))
-			
-			// Show a reasonable number of sensors that fit
-			sensorsToShow := len(m.systemTemperatures)
-			if sensorsToShow > 6 { // Limit to prevent overcrowding
-				sensorsToShow = 6
-			}
 
-			for i := 0; i < sensorsToShow; i++ {
-				sensor := m.systemTemperatures[i]
+			for _, sensor := range sensors {
 				// Use full sensor name, don't truncate unnecessarily
-				name := sensor.Name
+				name := sensor.name
 				if len(name) > 20 { // Only truncate if really long
 					name = name[:20]
 				}
 
-				// Color based on temperature
-				tempStr := fmt.Sprintf("%.0f°C", sensor.Temperature)
-				color := m.getTemperatureColor(sensor.Temperature)
-				tempStr = lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(tempStr)
+				valueStr := m.formatSensorValue(sensor.reading)
+				if color := m.getSensorColor(sensor.reading); color != "" {
+					valueStr = lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(valueStr)
+				}
 
-				content = append(content, fmt.Sprintf("%s: %s", name, tempStr))
+				content = append(content, fmt.Sprintf("%s: %s", name, valueStr))
 			}
 		}
 	}
@@ -147,4 +141,54 @@ func (m *ResponsiveTUIModel) renderMemDiskPanel(width, height int) string {
 	}
 
 	return style.Render(strings.Join(lines, "\n"))
-}
\ No newline at end of file
+}
+type sensorPanelReading struct {
+	name    string
+	reading *models.SensorReading
+}
+
+// sensorPanelReadings picks the readings shown in the SENSORS section,
+// temperatures first, then fans, then everything else
+func (m *ResponsiveTUIModel) sensorPanelReadings() []sensorPanelReading {
+	const maxSensors = 6 // Limit to prevent overcrowding
+
+	readings := make([]sensorPanelReading, 0, maxSensors)
+	for _, sensorType := range []string{"temp", "fan", ""} {
+		for _, chip := range m.sensors {
+			for _, reading := range chip.Readings {
+				if len(readings) == maxSensors {
+					return readings
+				}
+				switch sensorType {
+				case "":
+					if reading.Type == "temp" || reading.Type == "fan" {
+						continue
+					}
+				default:
+					if reading.Type != sensorType {
+						continue
+					}
+				}
+
+				// Unlabelled channels only make sense next to their chip
+				name := reading.Label
+				if name == reading.Key {
+					name = chip.Name + " " + reading.Key
+				}
+				readings = append(readings, sensorPanelReading{name: name, reading: reading})
+			}
+		}
+	}
+	return readings
+}
+
+func (m *ResponsiveTUIModel) formatSensorValue(reading *models.SensorReading) string {
+	switch reading.Type {
+	case "temp":
+		return fmt.Sprintf("%.0f%s", reading.Value, reading.Unit)
+	case "fan":
+		return fmt.Sprintf("%.0f %s", reading.Value, reading.Unit)
+	default:
+		return fmt.Sprintf("%.2f %s", reading.Value, reading.Unit)
+	}
+}
@@ -17,6 +17,7 @@ type NetworkSample struct {
 	txBytes   uint64
 	rxRate    float64
 	txRate    float64
+	errorRate float64 // Errors and drops per second, both directions
 }
 
 type DiskSample struct {
@@ -30,8 +31,8 @@ type DiskSample struct {
 
 type tickMsg time.Time
 
-func tick() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+func tick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -63,15 +64,28 @@ type ResponsiveTUIModel struct {
 	diskCursor     string
 	lastDiskUpdate time.Time
 
-	systemTemperatures []models.TemperatureSensor
-	lastTempUpdate     time.Time
+	sensors          []*models.SensorChip
+	lastSensorUpdate time.Time
 
 	sortBy      gops.ProcSortBy
 	procLimit   int
+	procCursor  string
 	ready       bool
 	showDetails bool
 	selectedPID int32
 
+	// Tree view shows subtree totals, rows line up with metrics.Processes
+	treeView        bool
+	processTreeRows []gops.ProcessTreeRow
+
+	// Extra /proc detail for the details panel, only fetched while it is shown
+	processDetail *models.ProcessDetail
+
+	// Signal and renice, confirmed in a dialog before they run
+	pendingAction    *processAction
+	actionStatus     string
+	actionStatusTime time.Time
+
 	distroLogo  []string
 	distroColor string
 	
@@ -81,6 +95,11 @@ type ResponsiveTUIModel struct {
 
 	hideCPUCores   bool
 	summarizeCores bool
+
+	refresh       time.Duration
+	rateRefresh   time.Duration
+	sensorRefresh time.Duration
+	units         config.Units
 }
 
 func (m *ResponsiveTUIModel) Cleanup() {
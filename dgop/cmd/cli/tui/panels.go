@@ -51,7 +51,8 @@ func (m *ResponsiveTUIModel) renderCPUPanel(width, height int) string {
 	cpuBar := m.renderProgressBar(uint64(cpu.Usage*100), 10000, barWidth, "cpu")
 	// Format as fixed-width strings for consistent alignment
 	usageText := fmt.Sprintf("%3.0f%%", cpu.Usage) // Always 3 chars for percentage (e.g. " 5%" or "100%")
-	tempText := fmt.Sprintf("%.0f°C", cpu.Temperature)
+	temp, tempUnit := m.units.ConvertTemperature(cpu.Temperature)
+	tempText := fmt.Sprintf("%.0f%s", temp, tempUnit)
 	content.WriteString(fmt.Sprintf("%s %s %s\n", cpuBar, usageText, tempText))
 
 	// Cores display - handle hide/summarize options
@@ -24,41 +24,52 @@ func (m *ResponsiveTUIModel) updateProcessTable() {
 		// Handle both 4-column and 5-column layouts
 		columns := m.processTable.Columns()
 		var row table.Row
+
+		cpu, memPercent, memKB, command := proc.CPU, proc.MemoryPercent, proc.MemoryKB, proc.Command
+		if m.treeView && i < len(m.processTreeRows) {
+			node := m.processTreeRows[i].Node
+			cpu, memPercent, memKB = node.SubtreeCPU, node.SubtreeMemoryPercent, node.SubtreeMemoryKB
+			command = m.processTreeRows[i].Prefix + command
+		}
 		
 		// Format memory to show both percentage and GB/MB
-		memGB := float64(proc.MemoryKB) / 1024 / 1024 // Convert KB to GB
+		memGB := float64(memKB) / 1024 / 1024 // Convert KB to GB
 		memMB := memGB * 1024
 		var memStr string
 		
 		// ALWAYS show both percentage and size for debugging
 		if memGB >= 1.0 {
-			memStr = fmt.Sprintf("%.1f%% %.1fG", proc.MemoryPercent, memGB)
+			memStr = fmt.Sprintf("%.1f%% %.1fG", memPercent, memGB)
 		} else {
-			memStr = fmt.Sprintf("%.1f%% %.0fM", proc.MemoryPercent, memMB)
+			memStr = fmt.Sprintf("%.1f%% %.0fM", memPercent, memMB)
 		}
 		
-		if len(columns) == 6 { // 6-column layout (PID, USER, CPU%, MEM%, COMMAND, FULL COMMAND)
-			commandWidth := columns[4].Width
-			fullCommandWidth := columns[5].Width
+		ioStr := m.formatBytes(uint64(proc.IOReadRate + proc.IOWriteRate))
+
+		if len(columns) == 7 { // 7-column layout (PID, USER, CPU%, MEM%, IO/s, COMMAND, FULL COMMAND)
+			commandWidth := columns[5].Width
+			fullCommandWidth := columns[6].Width
 			row = table.Row{
 				strconv.Itoa(int(proc.PID)),
 				truncateString(proc.Username, 12),
-				fmt.Sprintf("%.1f", proc.CPU),
+				fmt.Sprintf("%.1f", cpu),
 				memStr,
-				truncateString(proc.Command, commandWidth),
+				ioStr,
+				truncateString(command, commandWidth),
 				truncateString(proc.FullCommand, fullCommandWidth),
 			}
-		} else { // 5-column layout (original)
+		} else { // 6-column layout (original)
 			commandWidth := 30 // Default fallback
-			if len(columns) > 4 {
-				commandWidth = columns[4].Width
+			if len(columns) > 5 {
+				commandWidth = columns[5].Width
 			}
 			row = table.Row{
 				strconv.Itoa(int(proc.PID)),
 				truncateString(proc.Username, 12),
-				fmt.Sprintf("%.1f", proc.CPU),
+				fmt.Sprintf("%.1f", cpu),
 				memStr,
-				truncateString(proc.Command, commandWidth),
+				ioStr,
+				truncateString(command, commandWidth),
 			}
 		}
 		rows = append(rows, row)
@@ -104,6 +104,18 @@ func (m *ResponsiveTUIModel) getTemperatureColor(temp float64) string {
 	return colors.Temperature.Cold
 }
 
+// getSensorColor colors temperatures by heat and any reading with a raised
+// alarm as dangerous, other readings are left uncolored
+func (m *ResponsiveTUIModel) getSensorColor(reading *models.SensorReading) string {
+	if len(reading.Alarms) > 0 {
+		return m.getColors().Temperature.Danger
+	}
+	if reading.Type == "temp" {
+		return m.getTemperatureColor(reading.Value)
+	}
+	return ""
+}
+
 func (m *ResponsiveTUIModel) getNetworkColors() (string, string) {
 	colors := m.getColors()
 	return colors.Charts.NetworkDownload, colors.Charts.NetworkUpload
@@ -2,7 +2,9 @@ package tui
 
 import (
 	"fmt"
+	"math"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/AvengeMedia/dgop/gops"
@@ -15,7 +17,7 @@ import (
 var Version = "dev"
 
 func (m *ResponsiveTUIModel) Init() tea.Cmd {
-	cmds := []tea.Cmd{tick(), m.fetchData(), m.fetchTemperatureData()}
+	cmds := []tea.Cmd{tick(m.tickInterval()), m.fetchData(), m.fetchSensorData()}
 
 	if m.colorManager != nil {
 		cmds = append(cmds, m.listenForColorChanges())
@@ -42,6 +44,10 @@ func (m *ResponsiveTUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.ready = true
 
 	case tea.KeyMsg:
+		if m.pendingAction != nil {
+			return m, m.handleConfirmKey(msg)
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
@@ -61,6 +67,20 @@ func (m *ResponsiveTUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "p":
 			m.sortBy = gops.SortByPID
 			return m, m.fetchData()
+		case "i":
+			m.sortBy = gops.SortByIO
+			return m, m.fetchData()
+		case "t":
+			m.treeView = !m.treeView
+			return m, m.fetchData()
+		case "x":
+			m.confirmSignal(syscall.SIGTERM)
+		case "X":
+			m.confirmSignal(syscall.SIGKILL)
+		case "+":
+			m.confirmRenice(1)
+		case "-":
+			m.confirmRenice(-1)
 		case "up", "k":
 			oldCursor := m.processTable.Cursor()
 			m.processTable, cmd = m.processTable.Update(msg)
@@ -87,31 +107,35 @@ func (m *ResponsiveTUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tickMsg:
-		cmds = append(cmds, tick())
+		cmds = append(cmds, tick(m.tickInterval()))
 
 		now := time.Now()
 
-		// Update main metrics every second
-		if now.Sub(m.lastUpdate) >= 1*time.Second {
+		// Update main metrics, every second by default
+		if now.Sub(m.lastUpdate) >= m.refresh {
 			cmds = append(cmds, m.fetchData())
+
+			if proc := m.getSelectedProcess(); m.showDetails && proc != nil {
+				cmds = append(cmds, m.fetchProcessDetail(proc.PID))
+			}
 		}
 
-		// Update network rates every 2 seconds
-		if now.Sub(m.lastNetworkUpdate) >= 2*time.Second {
+		// Update network rates, every 2 seconds by default
+		if now.Sub(m.lastNetworkUpdate) >= m.rateRefresh {
 			cmds = append(cmds, m.fetchNetworkData())
 			m.lastNetworkUpdate = now
 		}
 
-		// Update disk rates every 2 seconds
-		if now.Sub(m.lastDiskUpdate) >= 2*time.Second {
+		// Update disk rates, every 2 seconds by default
+		if now.Sub(m.lastDiskUpdate) >= m.rateRefresh {
 			cmds = append(cmds, m.fetchDiskData())
 			m.lastDiskUpdate = now
 		}
 
-		// Update temperatures every 10 seconds
-		if now.Sub(m.lastTempUpdate) >= 10*time.Second {
-			cmds = append(cmds, m.fetchTemperatureData())
-			m.lastTempUpdate = now
+		// Update sensors, every 10 seconds by default
+		if now.Sub(m.lastSensorUpdate) >= m.sensorRefresh {
+			cmds = append(cmds, m.fetchSensorData())
+			m.lastSensorUpdate = now
 		}
 
 		// Logo cycling for testing - cycle every 3 seconds
@@ -130,6 +154,10 @@ func (m *ResponsiveTUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case fetchDataMsg:
 		m.metrics = msg.metrics
+		m.processTreeRows = msg.treeRows
+		if msg.procCursor != "" {
+			m.procCursor = msg.procCursor
+		}
 		m.err = msg.err
 		m.lastUpdate = time.Now()
 		m.updateProcessTable()
@@ -148,6 +176,8 @@ func (m *ResponsiveTUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					txBytes:   bestInterface.TxTotal,
 					rxRate:    bestInterface.RxRate,
 					txRate:    bestInterface.TxRate,
+					errorRate: bestInterface.RxErrorRate + bestInterface.TxErrorRate +
+						bestInterface.RxDropRate + bestInterface.TxDropRate,
 				}
 
 				m.networkHistory = append(m.networkHistory, sample)
@@ -202,9 +232,22 @@ func (m *ResponsiveTUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case fetchTempMsg:
+	case processActionMsg:
+		if msg.err != nil {
+			m.setActionStatus(msg.err.Error())
+		} else {
+			m.setActionStatus(msg.done)
+		}
+		cmds = append(cmds, m.fetchData())
+
+	case fetchProcessDetailMsg:
+		if msg.err == nil {
+			m.processDetail = msg.detail
+		}
+
+	case fetchSensorsMsg:
 		if msg.err == nil {
-			m.systemTemperatures = msg.temps
+			m.sensors = msg.chips
 		}
 
 	case colorUpdateMsg:
@@ -220,6 +263,10 @@ func (m *ResponsiveTUIModel) View() string {
 		return "Loading..."
 	}
 
+	if m.pendingAction != nil {
+		return m.renderConfirmDialog()
+	}
+
 	return m.renderLayout()
 }
 
@@ -362,12 +409,8 @@ func (m *ResponsiveTUIModel) minMemDiskLines(width int) int {
 		lines += 2 // blank + rates
 	}
 	// sensors block if present
-	if len(m.systemTemperatures) > 0 {
-		sensorsToShow := len(m.systemTemperatures)
-		if sensorsToShow > 6 {
-			sensorsToShow = 6
-		}
-		lines += 2 + sensorsToShow // blank + header + sensors
+	if sensors := m.sensorPanelReadings(); len(sensors) > 0 {
+		lines += 2 + len(sensors) // blank + header + sensors
 	}
 	return lines
 }
@@ -516,7 +559,10 @@ func (m *ResponsiveTUIModel) renderHeader() string {
 func (m *ResponsiveTUIModel) renderFooter() string {
 	style := m.footerStyle()
 
-	controls := "Controls: [q]uit [r]efresh [d]etails | Sort: [c]pu [m]emory [n]ame [p]id | ↑↓ Navigate"
+	controls := "Controls: [q]uit [r]efresh [d]etails | Sort: [c]pu [m]emory [n]ame [p]id [i]o | [t]ree | [x]/[X] term/kill [+/-] nice | ↑↓ Navigate"
+	if m.actionStatus != "" && time.Since(m.actionStatusTime) < actionStatusDuration {
+		controls = m.actionStatus
+	}
 	return style.Render(controls)
 }
 
@@ -536,6 +582,8 @@ func (m *ResponsiveTUIModel) renderProcessPanel(width, height int) string {
 		sortIndicator = " ↓NAME"
 	case gops.SortByPID:
 		sortIndicator = " ↓PID"
+	case gops.SortByIO:
+		sortIndicator = " ↓IO"
 	}
 
 	processCount := 0
@@ -544,6 +592,9 @@ func (m *ResponsiveTUIModel) renderProcessPanel(width, height int) string {
 	}
 
 	title := fmt.Sprintf("PROCESSES (%d)%s", processCount, sortIndicator)
+	if m.treeView {
+		title = fmt.Sprintf("PROCESS TREE (%d)%s, subtree totals", processCount, sortIndicator)
+	}
 	titleStyle := m.titleStyle()
 
 	content.WriteString(titleStyle.Render(title) + "\n")
@@ -588,8 +639,18 @@ func (m *ResponsiveTUIModel) renderProcessDetailsPanel(width, height int) string
 			} else {
 				content.WriteString(fmt.Sprintf("Memory: %.1f%% (%.0f MB)\n", proc.MemoryPercent, memGB*1024))
 			}
+			content.WriteString(fmt.Sprintf("Disk I/O: R %s/s  W %s/s\n", m.formatBytes(uint64(proc.IOReadRate)), m.formatBytes(uint64(proc.IOWriteRate))))
 			content.WriteString(fmt.Sprintf("Command: %s\n", proc.Command))
 
+			if detail := m.processDetail; detail != nil && detail.PID == proc.PID {
+				content.WriteString(fmt.Sprintf("State: %s (%s)\n", detail.State, detail.StateDescription))
+				content.WriteString(fmt.Sprintf("Started: %s\n", time.UnixMilli(detail.StartTime).Format("2006-01-02 15:04:05")))
+				content.WriteString(fmt.Sprintf("Nice: %d  Threads: %d  FDs: %d\n", detail.Nice, detail.Threads, detail.FDCount))
+				if detail.Cwd != "" && width > 20 {
+					content.WriteString(fmt.Sprintf("Cwd: %s\n", truncateString(detail.Cwd, width-11)))
+				}
+			}
+
 			// Show full command with word wrapping
 			maxWidth := width - 6
 			if len(proc.FullCommand) > maxWidth {
@@ -661,7 +722,12 @@ func (m *ResponsiveTUIModel) renderNetworkPanel(width, height int) string {
 	rxRateStr := m.formatBytes(uint64(latest.rxRate))
 	txRateStr := m.formatBytes(uint64(latest.txRate))
 
-	content.WriteString(fmt.Sprintf("↓%s/s ↑%s/s\n", rxRateStr, txRateStr))
+	content.WriteString(fmt.Sprintf("↓%s/s ↑%s/s", rxRateStr, txRateStr))
+	if latest.errorRate > 0 {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.getColors().Temperature.Danger))
+		content.WriteString(" " + errStyle.Render(fmt.Sprintf("⚠ %.0f err/s", math.Ceil(latest.errorRate))))
+	}
+	content.WriteString("\n")
 
 	// Build totals line first to know exact space needed
 	totalRx := m.formatBytes(latest.rxBytes)
@@ -714,16 +780,18 @@ func (m *ResponsiveTUIModel) updateProcessColumnWidthsForPanel(totalWidth int) {
 	userWidth := 6
 	cpuWidth := 5
 	memWidth := 13
+	ioWidth := 7
 
 	// If space is really tight, shrink fixed columns further
-	fixedColumnsWidth := pidWidth + userWidth + cpuWidth + memWidth
+	fixedColumnsWidth := pidWidth + userWidth + cpuWidth + memWidth + ioWidth
 	if availableWidth < fixedColumnsWidth+10 {
 		// Emergency shrink mode
 		pidWidth = 5
 		userWidth = 6
 		cpuWidth = 5
 		memWidth = 11
-		fixedColumnsWidth = pidWidth + userWidth + cpuWidth + memWidth
+		ioWidth = 6
+		fixedColumnsWidth = pidWidth + userWidth + cpuWidth + memWidth + ioWidth
 	}
 
 	// Check if we have enough space for 5th column (FULL COMMAND)
@@ -733,7 +801,7 @@ func (m *ResponsiveTUIModel) updateProcessColumnWidthsForPanel(totalWidth int) {
 
 	var columns []table.Column
 	if remainingWidth >= minCommandWidth+minFullCommandWidth+2 { // +2 for spacing
-		// 5-column layout with separate COMMAND and FULL COMMAND
+		// Layout with separate COMMAND and FULL COMMAND
 		commandWidth := minCommandWidth
 		fullCommandWidth := remainingWidth - commandWidth
 		if fullCommandWidth > 60 {
@@ -746,11 +814,12 @@ func (m *ResponsiveTUIModel) updateProcessColumnWidthsForPanel(totalWidth int) {
 			{Title: "USER", Width: userWidth},
 			{Title: "CPU%", Width: cpuWidth},
 			{Title: "MEM%", Width: memWidth},
+			{Title: "IO/s", Width: ioWidth},
 			{Title: "COMMAND", Width: commandWidth},
 			{Title: "FULL COMMAND", Width: fullCommandWidth},
 		}
 	} else {
-		// 4-column layout (original)
+		// Single COMMAND column (original)
 		commandWidth := remainingWidth
 		if commandWidth < 8 {
 			commandWidth = 8 // Absolute minimum
@@ -764,6 +833,7 @@ func (m *ResponsiveTUIModel) updateProcessColumnWidthsForPanel(totalWidth int) {
 			{Title: "USER", Width: userWidth},
 			{Title: "CPU%", Width: cpuWidth},
 			{Title: "MEM%", Width: memWidth},
+			{Title: "IO/s", Width: ioWidth},
 			{Title: "COMMAND", Width: commandWidth},
 		}
 	}
@@ -778,11 +848,11 @@ func (m *ResponsiveTUIModel) updateProcessColumnWidthsForPanel(totalWidth int) {
 }
 
 func (m *ResponsiveTUIModel) formatBytes(bytes uint64) string {
-	const unit = 1024
+	unit := uint64(m.units.ByteUnit())
 	if bytes < unit {
 		return fmt.Sprintf("%dB", bytes)
 	}
-	div, exp := int64(unit), 0
+	div, exp := unit, 0
 	for n := bytes / unit; n >= unit; n /= unit {
 		div *= unit
 		exp++
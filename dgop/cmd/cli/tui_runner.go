@@ -7,12 +7,12 @@ import (
 )
 
 func runTUI(gopsUtil *gops.GopsUtil) error {
-	return runTUIWithOptions(gopsUtil, false, false)
+	return runTUIWithOptions(gopsUtil, tui.DefaultOptions())
 }
 
-func runTUIWithOptions(gopsUtil *gops.GopsUtil, hideCPUCores, summarizeCores bool) error {
+func runTUIWithOptions(gopsUtil *gops.GopsUtil, opts tui.Options) error {
 	tui.Version = Version
-	model := tui.NewResponsiveTUIModelWithOptions(gopsUtil, hideCPUCores, summarizeCores)
+	model := tui.NewResponsiveTUIModelWithOptions(gopsUtil, opts)
 	defer model.Cleanup()
 
 	p := tea.NewProgram(
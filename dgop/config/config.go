@@ -1,21 +1,296 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 )
 
+// Config is ~/.config/dgop/config.json with environment variables layered
+// on top. Command-line flags in turn override both.
 type Config struct {
-	ApiPort string `env:"API_PORT" envDefault:":63484"` // Default port for the API server
+	Server    Server    `json:"server"`
+	Modules   []string  `json:"modules" env:"MODULES" envSeparator:","` // Modules dgop meta shows when --modules isn't given
+	Processes Processes `json:"processes"`
+	Filters   Filters   `json:"filters"`
+	TUI       TUI       `json:"tui"`
+	Units     Units     `json:"units"`
 }
 
-// Parse environment variables into a Config struct
-func NewConfig() *Config {
-	cfg := Config{}
+type Server struct {
+	Listen     string `json:"listen" env:"API_PORT"`         // host:port, :port for every address, a bare port is localhost only
+	SocketPath string `json:"unix_socket" env:"SOCKET_PATH"` // Unix socket for JSON-RPC, disabled when empty
+	DisableTCP bool   `json:"disable_tcp" env:"DISABLE_TCP"` // Only listen on the unix socket
+
+	// Accepted as "Authorization: Bearer <key>", X-API-Key or ?api_key=,
+	// the API is open when empty. The unix socket doesn't need one.
+	APIKeys []string `json:"api_keys" env:"API_KEYS" envSeparator:","`
+	// CIDRs or addresses allowed to connect over TCP, everyone when empty
+	AllowedClients []string `json:"allowed_clients" env:"ALLOWED_CLIENTS" envSeparator:","`
+	// Take the client address from CF-Connecting-IP, X-Real-Ip or the last
+	// X-Forwarded-For entry. Only safe behind a proxy that sets them.
+	TrustProxyHeaders bool `json:"trust_proxy_headers" env:"TRUST_PROXY_HEADERS"`
+	CORS              CORS `json:"cors"`
+	// Serve the signal and renice endpoints without API keys. With keys
+	// they are always served.
+	AllowProcessActions bool `json:"allow_process_actions" env:"ALLOW_PROCESS_ACTIONS"`
+
+	// Background sampler, lets clients get rates without passing cursors
+	Sampler            bool     `json:"sampler" env:"SAMPLER"`
+	SampleInterval     Duration `json:"sample_interval" env:"SAMPLE_INTERVAL"`
+	ProcSampleInterval Duration `json:"proc_sample_interval" env:"PROC_SAMPLE_INTERVAL"`
+}
+
+// CORS lets browser dashboards on other origins call the API
+type CORS struct {
+	AllowedOrigins []string `json:"allowed_origins" env:"CORS_ORIGINS" envSeparator:","` // * allows any origin, CORS is off when empty
+	AllowedHeaders []string `json:"allowed_headers" env:"CORS_HEADERS" envSeparator:","`
+	MaxAge         Duration `json:"max_age" env:"CORS_MAX_AGE"` // How long browsers may cache a preflight
+}
+
+// Processes are the defaults for process listings
+type Processes struct {
+	Limit int    `json:"limit" env:"PROC_LIMIT"` // 0 keeps every process
+	Sort  string `json:"sort" env:"PROC_SORT"`   // cpu, memory, name, pid or io
+}
+
+// Filters hold shell globs matched against network interface and disk
+// names. An include list replaces dgop's default filter, an exclude list
+// only the default exclude list.
+type Filters struct {
+	NetInclude  []string `json:"net_include" env:"NET_INCLUDE" envSeparator:","`
+	NetExclude  []string `json:"net_exclude" env:"NET_EXCLUDE" envSeparator:","`
+	DiskInclude []string `json:"disk_include" env:"DISK_INCLUDE" envSeparator:","`
+	DiskExclude []string `json:"disk_exclude" env:"DISK_EXCLUDE" envSeparator:","`
+}
+
+// TUI controls dgop top
+type TUI struct {
+	Refresh        Duration `json:"refresh" env:"TUI_REFRESH"`               // CPU, memory and processes
+	RateRefresh    Duration `json:"rate_refresh" env:"TUI_RATE_REFRESH"`     // Network and disk rates
+	SensorRefresh  Duration `json:"sensor_refresh" env:"TUI_SENSOR_REFRESH"` // hwmon sensors
+	HideCPUCores   bool     `json:"hide_cpu_cores" env:"TUI_HIDE_CPU_CORES"`
+	SummarizeCores bool     `json:"summarize_cores" env:"TUI_SUMMARIZE_CORES"`
+	ProcessTree    bool     `json:"process_tree" env:"TUI_PROCESS_TREE"` // Start in tree view
+}
+
+// Units pick how human-readable output shows sizes and temperatures. JSON
+// output always carries bytes and °C.
+type Units struct {
+	Bytes       string `json:"bytes" env:"UNITS_BYTES"`             // binary (steps of 1024) or decimal (steps of 1000)
+	Temperature string `json:"temperature" env:"UNITS_TEMPERATURE"` // celsius or fahrenheit
+}
+
+// Duration reads and writes strings such as 2s or 500ms
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+// Default is the configuration without a config file or environment
+func Default() Config {
+	return Config{
+		Server: Server{
+			Listen:             "127.0.0.1:63484",
+			SampleInterval:     Duration{time.Second},
+			ProcSampleInterval: Duration{2 * time.Second},
+			APIKeys:            []string{},
+			AllowedClients:     []string{},
+			CORS: CORS{
+				AllowedOrigins: []string{},
+				AllowedHeaders: []string{"Authorization", "Content-Type", "X-API-Key"},
+				MaxAge:         Duration{10 * time.Minute},
+			},
+		},
+		Modules: []string{"all"},
+		Processes: Processes{
+			Sort: "cpu",
+		},
+		Filters: Filters{
+			NetInclude:  []string{},
+			NetExclude:  []string{},
+			DiskInclude: []string{},
+			DiskExclude: []string{},
+		},
+		TUI: TUI{
+			Refresh:       Duration{time.Second},
+			RateRefresh:   Duration{2 * time.Second},
+			SensorRefresh: Duration{10 * time.Second},
+		},
+		Units: Units{
+			Bytes:       "binary",
+			Temperature: "celsius",
+		},
+	}
+}
+
+// Load reads the config file over the defaults, then lets environment
+// variables override it
+func Load() (*Config, error) {
+	cfg := Default()
+	if err := cfg.loadFile(); err != nil {
+		return nil, err
+	}
 	if err := env.Parse(&cfg); err != nil {
-		log.Fatal("Error parsing environment", "err", err)
+		return nil, fmt.Errorf("environment: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// NewConfig is Load for callers that can't go on without a configuration
+func NewConfig() *Config {
+	cfg, err := Load()
+	if err != nil {
+		log.Fatal("Error loading configuration", "err", err)
+	}
+	return cfg
+}
+
+func (cfg *Config) Validate() error {
+	if !slices.Contains([]string{"cpu", "memory", "name", "pid", "io"}, cfg.Processes.Sort) {
+		return fmt.Errorf("processes.sort: unknown sort %q", cfg.Processes.Sort)
+	}
+	if cfg.Processes.Limit < 0 {
+		return fmt.Errorf("processes.limit: must not be negative")
+	}
+	for name, d := range map[string]Duration{
+		"server.sample_interval":      cfg.Server.SampleInterval,
+		"server.proc_sample_interval": cfg.Server.ProcSampleInterval,
+		"tui.refresh":                 cfg.TUI.Refresh,
+		"tui.rate_refresh":            cfg.TUI.RateRefresh,
+		"tui.sensor_refresh":          cfg.TUI.SensorRefresh,
+	} {
+		if d.Duration <= 0 {
+			return fmt.Errorf("%s: must be positive", name)
+		}
+	}
+	if _, err := cfg.Server.ClientPrefixes(); err != nil {
+		return fmt.Errorf("server.allowed_clients: %w", err)
+	}
+	if slices.Contains(cfg.Server.APIKeys, "") {
+		return fmt.Errorf("server.api_keys: keys must not be empty")
 	}
+	if cfg.Units.Bytes != "binary" && cfg.Units.Bytes != "decimal" {
+		return fmt.Errorf("units.bytes: %q is neither binary nor decimal", cfg.Units.Bytes)
+	}
+	if cfg.Units.Temperature != "celsius" && cfg.Units.Temperature != "fahrenheit" {
+		return fmt.Errorf("units.temperature: %q is neither celsius nor fahrenheit", cfg.Units.Temperature)
+	}
+	return nil
+}
 
-	return &cfg
+// ListenAddr accepts a bare port as well as host:port, a bare port only
+// listens on localhost
+func (s Server) ListenAddr() string {
+	if s.Listen != "" && !strings.Contains(s.Listen, ":") {
+		return "127.0.0.1:" + s.Listen
+	}
+	return s.Listen
+}
+
+// ProcessActionsEnabled reports whether the API may signal and renice
+// processes. Without keys any web page could POST to those endpoints.
+func (s Server) ProcessActionsEnabled() bool {
+	return len(s.APIKeys) > 0 || s.AllowProcessActions
+}
+
+// ClientPrefixes parses AllowedClients, a plain address allows just itself
+func (s Server) ClientPrefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(s.AllowedClients))
+	for _, client := range s.AllowedClients {
+		if strings.Contains(client, "/") {
+			prefix, err := netip.ParsePrefix(client)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(client)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// ByteUnit is the step between B, KB, MB and so on
+func (u Units) ByteUnit() float64 {
+	if u.Bytes == "decimal" {
+		return 1000
+	}
+	return 1024
+}
+
+// ConvertTemperature returns celsius in the configured unit with its symbol
+func (u Units) ConvertTemperature(celsius float64) (float64, string) {
+	if u.Temperature == "fahrenheit" {
+		return celsius*9/5 + 32, "°F"
+	}
+	return celsius, "°C"
+}
+
+// FilePath returns ~/.config/dgop/config.json
+func FilePath() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "config.json"), nil
+}
+
+// loadFile fills cfg from the config file, which doesn't have to exist.
+// Settings the file leaves out keep their current value.
+func (cfg *Config) loadFile() error {
+	path, err := FilePath()
+	if err != nil {
+		// Without a home directory there is no config file to read
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// DefaultSocketPath returns $XDG_RUNTIME_DIR/dgop.sock, or a per-user path in
+// the temp directory when XDG_RUNTIME_DIR is not set
+func DefaultSocketPath() string {
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return filepath.Join(runtimeDir, "dgop.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("dgop-%d.sock", os.Getuid()))
 }
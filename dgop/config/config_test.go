@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfigFiltersFromFileAndEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".config", "dgop"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".config", "dgop", "config.json"), []byte(`{
+  "filters": {
+    "net_exclude": ["lo", "docker*"],
+    "disk_include": ["nvme*"]
+  }
+}`), 0o644))
+	t.Setenv("DISK_INCLUDE", "sd*,xvd*")
+
+	cfg := NewConfig()
+	assert.Equal(t, []string{"lo", "docker*"}, cfg.Filters.NetExclude)
+	assert.Empty(t, cfg.Filters.NetInclude)
+	assert.Equal(t, []string{"sd*", "xvd*"}, cfg.Filters.DiskInclude)
+}
+
+func TestNewConfigWithoutFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := NewConfig()
+	assert.Equal(t, "127.0.0.1:63484", cfg.Server.ListenAddr())
+	assert.Empty(t, cfg.Server.APIKeys)
+	assert.Equal(t, 2*time.Second, cfg.TUI.RateRefresh.Duration)
+	assert.Equal(t, "cpu", cfg.Processes.Sort)
+	assert.Empty(t, cfg.Filters.NetExclude)
+}
+
+func writeConfigFile(t *testing.T, content string) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".config", "dgop"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".config", "dgop", "config.json"), []byte(content), 0o644))
+}
+
+func TestLoadMergesFileEnvAndDefaults(t *testing.T) {
+	writeConfigFile(t, `{
+  "server": {"listen": "127.0.0.1:9000", "sampler": true},
+  "processes": {"limit": 25, "sort": "memory"},
+  "tui": {"refresh": "500ms"},
+  "units": {"bytes": "decimal"}
+}`)
+	t.Setenv("PROC_SORT", "name")
+	t.Setenv("UNITS_TEMPERATURE", "fahrenheit")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9000", cfg.Server.Listen)
+	assert.True(t, cfg.Server.Sampler)
+	assert.Equal(t, time.Second, cfg.Server.SampleInterval.Duration)
+	assert.Equal(t, 25, cfg.Processes.Limit)
+	assert.Equal(t, "name", cfg.Processes.Sort)
+	assert.Equal(t, 500*time.Millisecond, cfg.TUI.Refresh.Duration)
+	assert.Equal(t, 10*time.Second, cfg.TUI.SensorRefresh.Duration)
+	assert.Equal(t, 1000.0, cfg.Units.ByteUnit())
+
+	temp, unit := cfg.Units.ConvertTemperature(100)
+	assert.InDelta(t, 212.0, temp, 0.001)
+	assert.Equal(t, "°F", unit)
+}
+
+func TestLoadRejectsInvalidConfig(t *testing.T) {
+	writeConfigFile(t, `{"processes": {"sort": "size"}}`)
+	_, err := Load()
+	assert.ErrorContains(t, err, "processes.sort")
+
+	writeConfigFile(t, `{"server": {"allowed_clients": ["10.0.0.0/33"]}}`)
+	_, err = Load()
+	assert.ErrorContains(t, err, "server.allowed_clients")
+
+	writeConfigFile(t, `{"tui": {"refresh": "soon"}}`)
+	_, err = Load()
+	assert.ErrorContains(t, err, "config.json")
+}
+
+func TestListenAddr(t *testing.T) {
+	assert.Equal(t, "127.0.0.1:8080", Server{Listen: "8080"}.ListenAddr())
+	assert.Equal(t, ":8080", Server{Listen: ":8080"}.ListenAddr())
+	assert.Equal(t, "localhost:8080", Server{Listen: "localhost:8080"}.ListenAddr())
+}
+
+func TestClientPrefixes(t *testing.T) {
+	prefixes, err := Server{AllowedClients: []string{"192.168.1.7/24", "10.0.0.5", "::1"}}.ClientPrefixes()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.168.1.0/24", "10.0.0.5/32", "::1/128"}, []string{prefixes[0].String(), prefixes[1].String(), prefixes[2].String()})
+
+	_, err = Server{AllowedClients: []string{"localhost"}}.ClientPrefixes()
+	assert.Error(t, err)
+}
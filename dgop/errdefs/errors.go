@@ -38,19 +38,22 @@ type ErrorType int
 // Errors
 var (
 	// Permissions
-	ErrInvalidInput = NewCustomError(ErrTypeInvalidInput, "")
-	ErrNotFound     = NewCustomError(ErrTypeNotFound, "")
+	ErrInvalidInput     = NewCustomError(ErrTypeInvalidInput, "")
+	ErrNotFound         = NewCustomError(ErrTypeNotFound, "")
+	ErrPermissionDenied = NewCustomError(ErrTypePermissionDenied, "")
 )
 
 // More dynamic errors
 const (
 	ErrTypeInvalidInput ErrorType = iota
 	ErrTypeNotFound
+	ErrTypePermissionDenied
 )
 
 var errorTypeStrings = map[ErrorType]string{
-	ErrTypeInvalidInput: "ErrInvalidInput",
-	ErrTypeNotFound:     "ErrNotFound",
+	ErrTypeInvalidInput:     "ErrInvalidInput",
+	ErrTypeNotFound:         "ErrNotFound",
+	ErrTypePermissionDenied: "ErrPermissionDenied",
 }
 
 func (e ErrorType) String() string {
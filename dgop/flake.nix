@@ -52,7 +52,7 @@
             installPhase = ''
               mkdir -p $out/bin
               cp $GOPATH/bin/cli $out/bin/dgop
-              wrapProgram $out/bin/dgop --prefix PATH : "${lib.makeBinPath [ pkgs.pciutils ]}"
+              wrapProgram $out/bin/dgop --set-default DGOP_PCI_IDS "${pkgs.hwdata}/share/hwdata/pci.ids"
             '';
 
             meta = {
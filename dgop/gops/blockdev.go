@@ -0,0 +1,191 @@
+package gops
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AvengeMedia/dgop/models"
+)
+
+// GetBlockDevices lists /sys/block, leaving out devices with no size such
+// as unused loop devices. SMART health needs smartctl and usually root.
+func (self *GopsUtil) GetBlockDevices(withSMART bool) ([]*models.BlockDevice, error) {
+	blockDir := self.sysPath("block")
+	entries, err := os.ReadDir(blockDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make([]*models.BlockDevice, 0), nil
+		}
+		return nil, err
+	}
+
+	devices := make([]*models.BlockDevice, 0, len(entries))
+	for _, entry := range entries {
+		device := readBlockDevice(filepath.Join(blockDir, entry.Name()))
+		if device.Size == 0 {
+			continue
+		}
+		if withSMART && device.Type == "disk" {
+			device.SMART = readSMART(device.Name)
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
+
+func readBlockDevice(dir string) *models.BlockDevice {
+	name := filepath.Base(dir)
+	attr := func(elem ...string) string { return filepath.Join(append([]string{dir}, elem...)...) }
+
+	device := &models.BlockDevice{
+		Name:       name,
+		Type:       blockDeviceType(dir, name),
+		Model:      readSysfsString(attr("device", "model")),
+		Vendor:     readSysfsString(attr("device", "vendor")),
+		Serial:     readSysfsString(attr("device", "serial")),
+		Scheduler:  selectedOption(readSysfsString(attr("queue", "scheduler"))),
+		DMName:     readSysfsString(attr("dm", "name")),
+		DMType:     dmType(readSysfsString(attr("dm", "uuid"))),
+		Holders:    listDir(attr("holders")),
+		Slaves:     listDir(attr("slaves")),
+		Partitions: make([]*models.BlockPartition, 0),
+	}
+	// virtio keeps the serial on the disk rather than the device
+	if device.Serial == "" {
+		device.Serial = readSysfsString(attr("serial"))
+	}
+	if device.Scheduler == "none" {
+		device.Scheduler = ""
+	}
+
+	sectors, _ := readSysfsInt(attr("size"))
+	device.Size = uint64(sectors) * 512
+	device.Rotational = readSysfsString(attr("queue", "rotational")) == "1"
+	device.Removable = readSysfsString(attr("removable")) == "1"
+	device.ReadOnly = readSysfsString(attr("ro")) == "1"
+
+	// SCSI and SATA report the device's own queue, others the block layer's
+	if depth, ok := readSysfsInt(attr("device", "queue_depth")); ok {
+		device.QueueDepth = int(depth)
+	} else if depth, ok := readSysfsInt(attr("queue", "nr_requests")); ok {
+		device.QueueDepth = int(depth)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		partDir := filepath.Join(dir, entry.Name())
+		number, ok := readSysfsInt(filepath.Join(partDir, "partition"))
+		if !ok {
+			continue
+		}
+		start, _ := readSysfsInt(filepath.Join(partDir, "start"))
+		size, _ := readSysfsInt(filepath.Join(partDir, "size"))
+		device.Partitions = append(device.Partitions, &models.BlockPartition{
+			Name:     entry.Name(),
+			Number:   int(number),
+			Start:    uint64(start) * 512,
+			Size:     uint64(size) * 512,
+			ReadOnly: readSysfsString(filepath.Join(partDir, "ro")) == "1",
+			Holders:  listDir(filepath.Join(partDir, "holders")),
+		})
+	}
+	sort.Slice(device.Partitions, func(i, j int) bool {
+		return device.Partitions[i].Number < device.Partitions[j].Number
+	})
+
+	return device
+}
+
+func blockDeviceType(dir, name string) string {
+	if _, err := os.Stat(filepath.Join(dir, "dm")); err == nil {
+		return "dm"
+	}
+	for _, prefix := range []string{"loop", "zram", "md"} {
+		if strings.HasPrefix(name, prefix) {
+			return prefix
+		}
+	}
+	if strings.HasPrefix(name, "sr") {
+		return "rom"
+	}
+	return "disk"
+}
+
+// dmType reads the target from a device-mapper uuid such as
+// CRYPT-LUKS2-<uuid>-cryptroot or LVM-<vg uuid><lv uuid>
+func dmType(uuid string) string {
+	prefix, _, ok := strings.Cut(uuid, "-")
+	if !ok {
+		return ""
+	}
+	return strings.ToLower(prefix)
+}
+
+// listDir returns the entry names of dir, empty when it can't be read
+func listDir(dir string) []string {
+	names := make([]string, 0)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return names
+	}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+type smartctlOutput struct {
+	SmartStatus *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature struct {
+		Current float64 `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime struct {
+		Hours uint64 `json:"hours"`
+	} `json:"power_on_time"`
+	PowerCycleCount uint64 `json:"power_cycle_count"`
+	NVMeHealth      struct {
+		PercentageUsed int `json:"percentage_used"`
+	} `json:"nvme_smart_health_information_log"`
+}
+
+// smartctlTimeout bounds a smartctl run, a bad USB bridge can hang it
+var smartctlTimeout = 5 * time.Second
+
+// readSMART returns nil when smartctl is missing, can't read the disk, times
+// out or finds the disk spun down. -n standby leaves sleeping disks asleep
+// rather than waking them on every poll.
+func readSMART(name string) *models.SMARTInfo {
+	ctx, cancel := context.WithTimeout(context.Background(), smartctlTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "smartctl", "--json", "-n", "standby", "-H", "-A", "-i", "/dev/"+name)
+	cmd.WaitDelay = time.Second
+	// smartctl's exit status is a bit mask that is non-zero for plenty of
+	// healthy disks, so go by whether the JSON has a verdict
+	output, _ := cmd.Output()
+	return parseSMART(output)
+}
+
+func parseSMART(output []byte) *models.SMARTInfo {
+	var result smartctlOutput
+	if err := json.Unmarshal(output, &result); err != nil || result.SmartStatus == nil {
+		return nil
+	}
+
+	return &models.SMARTInfo{
+		Passed:         result.SmartStatus.Passed,
+		Temperature:    result.Temperature.Current,
+		PowerOnHours:   result.PowerOnTime.Hours,
+		PowerCycles:    result.PowerCycleCount,
+		PercentageUsed: result.NVMeHealth.PercentageUsed,
+	}
+}
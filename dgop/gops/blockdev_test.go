@@ -0,0 +1,151 @@
+package gops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func blockFixture(t *testing.T) string {
+	root := t.TempDir()
+	writeSysfsAttrs(t, filepath.Join(root, "block", "nvme0n1"), map[string]string{
+		"size":                "1000215216",
+		"removable":           "0",
+		"ro":                  "0",
+		"queue/rotational":    "0",
+		"queue/scheduler":     "[none] mq-deadline",
+		"queue/nr_requests":   "1023",
+		"device/model":        "Samsung SSD 980 PRO 1TB",
+		"device/serial":       "S5GXNX0T123456",
+		"nvme0n1p2/partition": "2",
+		"nvme0n1p2/start":     "1050624",
+		"nvme0n1p2/size":      "999161856",
+		"nvme0n1p1/partition": "1",
+		"nvme0n1p1/start":     "2048",
+		"nvme0n1p1/size":      "1048576",
+	})
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "block", "nvme0n1", "nvme0n1p2", "holders", "dm-0"), 0o755))
+
+	writeSysfsAttrs(t, filepath.Join(root, "block", "sda"), map[string]string{
+		"size":               "3907029168",
+		"removable":          "0",
+		"queue/rotational":   "1",
+		"queue/scheduler":    "mq-deadline kyber [bfq] none",
+		"queue/nr_requests":  "64",
+		"device/vendor":      "ATA",
+		"device/model":       "WDC WD20EZRZ-00Z",
+		"device/queue_depth": "32",
+	})
+	writeSysfsAttrs(t, filepath.Join(root, "block", "dm-0"), map[string]string{
+		"size":    "999129088",
+		"dm/name": "cryptroot",
+		"dm/uuid": "CRYPT-LUKS2-0123456789abcdef0123456789abcdef-cryptroot",
+	})
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "block", "dm-0", "slaves", "nvme0n1p2"), 0o755))
+
+	writeSysfsAttrs(t, filepath.Join(root, "block", "loop0"), map[string]string{"size": "0"})
+	writeSysfsAttrs(t, filepath.Join(root, "block", "sr0"), map[string]string{"size": "2097151", "removable": "1", "ro": "1"})
+	return root
+}
+
+func TestGetBlockDevices(t *testing.T) {
+	devices, err := (&GopsUtil{sysRoot: blockFixture(t)}).GetBlockDevices(false)
+	require.NoError(t, err)
+	require.Len(t, devices, 4)
+
+	byName := make(map[string]int)
+	for i, device := range devices {
+		byName[device.Name] = i
+	}
+	assert.NotContains(t, byName, "loop0")
+
+	nvme := devices[byName["nvme0n1"]]
+	assert.Equal(t, "disk", nvme.Type)
+	assert.Equal(t, "Samsung SSD 980 PRO 1TB", nvme.Model)
+	assert.Equal(t, "S5GXNX0T123456", nvme.Serial)
+	assert.Equal(t, uint64(1000215216*512), nvme.Size)
+	assert.False(t, nvme.Rotational)
+	assert.Empty(t, nvme.Scheduler)
+	assert.Equal(t, 1023, nvme.QueueDepth)
+	assert.Nil(t, nvme.SMART)
+	require.Len(t, nvme.Partitions, 2)
+	assert.Equal(t, "nvme0n1p1", nvme.Partitions[0].Name)
+	assert.Equal(t, uint64(2048*512), nvme.Partitions[0].Start)
+	assert.Equal(t, 2, nvme.Partitions[1].Number)
+	assert.Equal(t, []string{"dm-0"}, nvme.Partitions[1].Holders)
+
+	sda := devices[byName["sda"]]
+	assert.True(t, sda.Rotational)
+	assert.Equal(t, "ATA", sda.Vendor)
+	assert.Equal(t, "bfq", sda.Scheduler)
+	assert.Equal(t, 32, sda.QueueDepth)
+	assert.Empty(t, sda.Partitions)
+
+	dm := devices[byName["dm-0"]]
+	assert.Equal(t, "dm", dm.Type)
+	assert.Equal(t, "cryptroot", dm.DMName)
+	assert.Equal(t, "crypt", dm.DMType)
+	assert.Equal(t, []string{"nvme0n1p2"}, dm.Slaves)
+
+	rom := devices[byName["sr0"]]
+	assert.Equal(t, "rom", rom.Type)
+	assert.True(t, rom.Removable)
+	assert.True(t, rom.ReadOnly)
+}
+
+func TestGetBlockDevicesSMART(t *testing.T) {
+	// Bit 6 of the exit status: errors in the device log, still a verdict
+	fakeCommand(t, "smartctl", printAndExit(`{
+  "smart_status": {"passed": true},
+  "temperature": {"current": 41},
+  "power_on_time": {"hours": 12034},
+  "power_cycle_count": 871,
+  "nvme_smart_health_information_log": {"percentage_used": 3}
+}
+`, 64))
+
+	devices, err := (&GopsUtil{sysRoot: blockFixture(t)}).GetBlockDevices(true)
+	require.NoError(t, err)
+
+	for _, device := range devices {
+		if device.Type != "disk" {
+			assert.Nil(t, device.SMART, device.Name)
+			continue
+		}
+		require.NotNil(t, device.SMART, device.Name)
+		assert.True(t, device.SMART.Passed)
+		assert.Equal(t, 41.0, device.SMART.Temperature)
+		assert.Equal(t, uint64(12034), device.SMART.PowerOnHours)
+		assert.Equal(t, uint64(871), device.SMART.PowerCycles)
+		assert.Equal(t, 3, device.SMART.PercentageUsed)
+	}
+}
+
+func TestReadSMARTLeavesStandbyDisksAsleep(t *testing.T) {
+	// smartctl -n standby exits with 2 and no verdict for a sleeping disk
+	fakeCommand(t, "smartctl", `case "$*" in
+*"-n standby"*) echo '{"smartctl": {"exit_status": 2}}'; exit 2 ;;
+*) echo '{"smart_status": {"passed": true}}' ;;
+esac
+`)
+	assert.Nil(t, readSMART("sda"))
+}
+
+func TestReadSMARTTimesOut(t *testing.T) {
+	fakeCommand(t, "smartctl", "exec sleep 10\n")
+	defer func(timeout time.Duration) { smartctlTimeout = timeout }(smartctlTimeout)
+	smartctlTimeout = 100 * time.Millisecond
+
+	start := time.Now()
+	assert.Nil(t, readSMART("sda"))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestParseSMARTWithoutVerdict(t *testing.T) {
+	assert.Nil(t, parseSMART([]byte(`{"smartctl": {"exit_status": 2}}`)))
+	assert.Nil(t, parseSMART(nil))
+}
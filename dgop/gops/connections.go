@@ -0,0 +1,265 @@
+package gops
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AvengeMedia/dgop/errdefs"
+	"github.com/AvengeMedia/dgop/models"
+)
+
+var connectionProtocols = []string{"tcp", "tcp6", "udp", "udp6", "unix"}
+
+// From include/net/tcp_states.h
+var tcpStates = map[string]string{
+	"01": "ESTABLISHED",
+	"02": "SYN_SENT",
+	"03": "SYN_RECV",
+	"04": "FIN_WAIT1",
+	"05": "FIN_WAIT2",
+	"06": "TIME_WAIT",
+	"07": "CLOSE",
+	"08": "CLOSE_WAIT",
+	"09": "LAST_ACK",
+	"0A": "LISTEN",
+	"0B": "CLOSING",
+	"0C": "NEW_SYN_RECV",
+}
+
+// From include/uapi/linux/net.h
+var unixStates = map[string]string{
+	"01": "UNCONN",
+	"02": "CONNECTING",
+	"03": "CONNECTED",
+	"04": "DISCONNECTING",
+}
+
+// __SO_ACCEPTCON, set on unix sockets that called listen()
+const unixAcceptCon = 1 << 16
+
+// GetConnections lists the sockets of the given protocols, all of them when
+// protocols is empty. Owning processes are only found for sockets whose
+// process fds are readable, so running as root shows every owner.
+func (self *GopsUtil) GetConnections(protocols []string, listeningOnly bool) ([]*models.ConnectionInfo, error) {
+	return readConnections("/proc", protocols, listeningOnly)
+}
+
+func readConnections(procRoot string, protocols []string, listeningOnly bool) ([]*models.ConnectionInfo, error) {
+	if len(protocols) == 0 {
+		protocols = connectionProtocols
+	}
+
+	conns := make([]*models.ConnectionInfo, 0)
+	for _, proto := range protocols {
+		if !slices.Contains(connectionProtocols, proto) {
+			return nil, errdefs.NewCustomError(errdefs.ErrTypeInvalidInput, fmt.Sprintf("unknown protocol %q", proto))
+		}
+
+		// tcp6 and udp6 are missing when IPv6 is disabled
+		data, err := os.ReadFile(filepath.Join(procRoot, "net", proto))
+		if err != nil {
+			continue
+		}
+
+		if proto == "unix" {
+			conns = append(conns, parseProcNetUnix(string(data))...)
+		} else {
+			conns = append(conns, parseProcNetInet(string(data), proto)...)
+		}
+	}
+
+	if listeningOnly {
+		conns = slices.DeleteFunc(conns, func(conn *models.ConnectionInfo) bool {
+			return !conn.Listening
+		})
+	}
+
+	owners := readSocketOwners(procRoot)
+	for _, conn := range conns {
+		if owner, ok := owners[conn.Inode]; ok {
+			conn.PID = owner.pid
+			conn.Command = owner.command
+		}
+	}
+
+	sort.SliceStable(conns, func(i, j int) bool {
+		if conns[i].Protocol != conns[j].Protocol {
+			return conns[i].Protocol < conns[j].Protocol
+		}
+		return conns[i].LocalPort < conns[j].LocalPort
+	})
+
+	return conns, nil
+}
+
+// parseProcNetInet parses /proc/net/{tcp,tcp6,udp,udp6}
+func parseProcNetInet(data, proto string) []*models.ConnectionInfo {
+	conns := make([]*models.ConnectionInfo, 0)
+	isUDP := strings.HasPrefix(proto, "udp")
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Scan() // Header
+	for scanner.Scan() {
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+
+		localAddr, localPort, err := parseHexEndpoint(fields[1])
+		if err != nil {
+			continue
+		}
+		remoteAddr, remotePort, err := parseHexEndpoint(fields[2])
+		if err != nil {
+			continue
+		}
+		uid, _ := strconv.ParseUint(fields[7], 10, 32)
+		inode, _ := strconv.ParseUint(fields[9], 10, 64)
+
+		conn := &models.ConnectionInfo{
+			Protocol:   proto,
+			LocalAddr:  localAddr,
+			LocalPort:  localPort,
+			RemoteAddr: remoteAddr,
+			RemotePort: remotePort,
+			State:      tcpStates[fields[3]],
+			Inode:      inode,
+			UID:        uint32(uid),
+		}
+
+		// UDP reuses the tcp states, an unconnected bound socket is CLOSE
+		if isUDP && conn.State == "CLOSE" {
+			conn.State = "UNCONN"
+			conn.Listening = true
+		} else {
+			conn.Listening = conn.State == "LISTEN"
+		}
+
+		conns = append(conns, conn)
+	}
+
+	return conns
+}
+
+// parseHexEndpoint decodes 0100007F:0035 style addresses. The address is
+// made of 32 bit words in host byte order, the port is big endian hex.
+func parseHexEndpoint(s string) (string, uint16, error) {
+	addrHex, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", 0, fmt.Errorf("malformed endpoint %q", s)
+	}
+
+	raw, err := hex.DecodeString(addrHex)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return "", 0, fmt.Errorf("malformed address %q", addrHex)
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return "", 0, fmt.Errorf("malformed port %q", portHex)
+	}
+
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		binary.BigEndian.PutUint32(ip[i:], binary.NativeEndian.Uint32(raw[i:]))
+	}
+
+	return ip.String(), uint16(port), nil
+}
+
+// parseProcNetUnix parses /proc/net/unix
+func parseProcNetUnix(data string) []*models.ConnectionInfo {
+	conns := make([]*models.ConnectionInfo, 0)
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Scan() // Header
+	for scanner.Scan() {
+		// Num RefCount Protocol Flags Type St Inode Path
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 7 {
+			continue
+		}
+
+		flags, _ := strconv.ParseUint(fields[3], 16, 32)
+		inode, _ := strconv.ParseUint(fields[6], 10, 64)
+
+		conn := &models.ConnectionInfo{
+			Protocol:  "unix",
+			State:     unixStates[fields[5]],
+			Listening: flags&unixAcceptCon != 0,
+			Inode:     inode,
+		}
+		if conn.Listening {
+			conn.State = "LISTEN"
+		}
+		if len(fields) > 7 {
+			// Paths may contain spaces
+			conn.LocalAddr = strings.Join(fields[7:], " ")
+		}
+
+		conns = append(conns, conn)
+	}
+
+	return conns
+}
+
+type socketOwner struct {
+	pid     int32
+	command string
+}
+
+// readSocketOwners maps socket inodes to the first process found holding them
+func readSocketOwners(procRoot string) map[uint64]socketOwner {
+	owners := make(map[uint64]socketOwner)
+
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return owners
+	}
+
+	for _, entry := range entries {
+		pid, err := strconv.ParseInt(entry.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+
+		fdDir := filepath.Join(procRoot, entry.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+
+		var command string
+		for _, fd := range fds {
+			// Links look like socket:[12345]
+			target, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil || !strings.HasPrefix(target, "socket:[") {
+				continue
+			}
+			inode, err := strconv.ParseUint(strings.TrimSuffix(target[len("socket:["):], "]"), 10, 64)
+			if err != nil {
+				continue
+			}
+			if _, seen := owners[inode]; seen {
+				continue
+			}
+
+			if command == "" {
+				comm, _ := os.ReadFile(filepath.Join(procRoot, entry.Name(), "comm"))
+				command = strings.TrimSpace(string(comm))
+			}
+			owners[inode] = socketOwner{pid: int32(pid), command: command}
+		}
+	}
+
+	return owners
+}
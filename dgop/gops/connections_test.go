@@ -0,0 +1,99 @@
+package gops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const procNetTCP = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:0035 00000000:0000 0A 00000000:00000000 00:00000000 00000000   101        0 1001 1 0000000000000000 100 0 0 10 0
+   1: 0F02000A:C350 2C0B1AC6:01BB 01 00000000:00000000 02:000A7D5C 00000000  1000        0 1002 2 0000000000000000 20 4 28 10 -1
+`
+
+const procNetUDP6 = `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+  0: 00000000000000000000000000000000:14E9 00000000000000000000000000000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 1003 2 0000000000000000 0
+`
+
+const procNetUnix = `Num       RefCount Protocol Flags    Type St Inode Path
+0000000000000000: 00000002 00000000 00010000 0001 01 1004 /run/dbus/system_bus_socket
+0000000000000000: 00000003 00000000 00000000 0001 03 1005
+0000000000000000: 00000002 00000000 00010000 0001 01 1006 /tmp/My Files/app socket
+`
+
+func writeFixture(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// writeSysfsAttrs creates dir with one file per attribute, each value
+// followed by a newline as the kernel prints it
+func writeSysfsAttrs(t *testing.T, dir string, attrs map[string]string) {
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	for attr, value := range attrs {
+		writeFixture(t, filepath.Join(dir, attr), value+"\n")
+	}
+}
+
+func TestParseHexEndpoint(t *testing.T) {
+	addr, port, err := parseHexEndpoint("0100007F:0035")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", addr)
+	assert.Equal(t, uint16(53), port)
+
+	addr, port, err = parseHexEndpoint("00000000000000000000000001000000:0016")
+	require.NoError(t, err)
+	assert.Equal(t, "::1", addr)
+	assert.Equal(t, uint16(22), port)
+
+	_, _, err = parseHexEndpoint("0100007F")
+	assert.Error(t, err)
+}
+
+func TestReadConnections(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, filepath.Join(root, "net", "tcp"), procNetTCP)
+	writeFixture(t, filepath.Join(root, "net", "udp6"), procNetUDP6)
+	writeFixture(t, filepath.Join(root, "net", "unix"), procNetUnix)
+
+	writeFixture(t, filepath.Join(root, "42", "comm"), "dnsmasq\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "42", "fd"), 0o755))
+	require.NoError(t, os.Symlink("socket:[1001]", filepath.Join(root, "42", "fd", "3")))
+	require.NoError(t, os.Symlink("/dev/null", filepath.Join(root, "42", "fd", "0")))
+
+	conns, err := readConnections(root, nil, false)
+	require.NoError(t, err)
+	require.Len(t, conns, 6)
+
+	dns := conns[0]
+	assert.Equal(t, "tcp", dns.Protocol)
+	assert.Equal(t, "LISTEN", dns.State)
+	assert.True(t, dns.Listening)
+	assert.Equal(t, uint32(101), dns.UID)
+	assert.Equal(t, int32(42), dns.PID)
+	assert.Equal(t, "dnsmasq", dns.Command)
+
+	https := conns[1]
+	assert.Equal(t, "10.0.2.15", https.LocalAddr)
+	assert.Equal(t, "198.26.11.44", https.RemoteAddr)
+	assert.Equal(t, uint16(443), https.RemotePort)
+	assert.Equal(t, "ESTABLISHED", https.State)
+	assert.False(t, https.Listening)
+	assert.Zero(t, https.PID)
+
+	listening, err := readConnections(root, nil, true)
+	require.NoError(t, err)
+	require.Len(t, listening, 4)
+	assert.Equal(t, "udp6", listening[1].Protocol)
+	assert.Equal(t, "UNCONN", listening[1].State)
+	assert.Equal(t, uint16(5353), listening[1].LocalPort)
+	assert.Equal(t, "unix", listening[2].Protocol)
+	assert.Equal(t, "/run/dbus/system_bus_socket", listening[2].LocalAddr)
+	assert.Equal(t, "/tmp/My Files/app socket", listening[3].LocalAddr)
+
+	_, err = readConnections(root, []string{"sctp"}, false)
+	assert.Error(t, err)
+}
@@ -41,6 +41,16 @@ func (self *GopsUtil) GetCPUInfo() (*models.CPUInfo, error) {
 }
 
 func (self *GopsUtil) GetCPUInfoWithCursor(cursor string) (*models.CPUInfo, error) {
+	if cursor == "" {
+		if cached, ok := self.sampler.latestCPU(); ok {
+			return cached, nil
+		}
+	}
+
+	return self.collectCPUInfo(cursor)
+}
+
+func (self *GopsUtil) collectCPUInfo(cursor string) (*models.CPUInfo, error) {
 	cpuInfo := models.CPUInfo{}
 
 	cpuTracker.mu.Lock()
@@ -0,0 +1,101 @@
+package gops
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/AvengeMedia/dgop/errdefs"
+)
+
+// DeviceFilter picks network interfaces or disks by name with shell globs
+// such as wlp* or nvme?n1. A name has to match one of Include, when there
+// are any, and none of Exclude.
+type DeviceFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// DeviceFilters are the filters a GopsUtil applies when a request doesn't
+// bring its own
+type DeviceFilters struct {
+	Interfaces DeviceFilter
+	Disks      DeviceFilter
+}
+
+// DefaultInterfaceFilter keeps everything but loopback, container veth
+// pairs and the fallback devices tunnel modules create on load
+var DefaultInterfaceFilter = DeviceFilter{
+	Exclude: []string{"lo", "veth*", "ifb*", "dummy*", "sit0", "tunl0", "ip6tnl0", "ip_vti0", "ip6_vti0", "gre0", "gretap0", "erspan0", "ip6gre0"},
+}
+
+// DefaultDiskFilter keeps real disks, their partitions and dm/md devices,
+// leaving out loop, ram and zram devices
+var DefaultDiskFilter = DeviceFilter{
+	Include: []string{"sd*", "hd*", "vd*", "xvd*", "nvme*", "mmcblk*", "dm-*", "md*", "sr*"},
+}
+
+// Override layers other over f. An Include list names exactly what is
+// wanted, so it replaces f altogether, while an Exclude list on its own
+// only replaces f's Exclude.
+func (f DeviceFilter) Override(other DeviceFilter) DeviceFilter {
+	if len(other.Include) > 0 {
+		return other
+	}
+	if len(other.Exclude) > 0 {
+		f.Exclude = other.Exclude
+	}
+	return f
+}
+
+func (f DeviceFilter) Validate() error {
+	for _, pattern := range append(append([]string{}, f.Include...), f.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errdefs.NewCustomError(errdefs.ErrTypeInvalidInput, fmt.Sprintf("invalid device pattern %q: %v", pattern, err))
+		}
+	}
+	return nil
+}
+
+func (f DeviceFilter) Match(name string) bool {
+	if len(f.Include) > 0 && !matchesAnyGlob(f.Include, name) {
+		return false
+	}
+	return !matchesAnyGlob(f.Exclude, name)
+}
+
+func matchesAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDeviceFilters replaces the configured filters, which are layered over
+// the defaults with Override
+func (self *GopsUtil) SetDeviceFilters(filters DeviceFilters) error {
+	if err := filters.Interfaces.Validate(); err != nil {
+		return err
+	}
+	if err := filters.Disks.Validate(); err != nil {
+		return err
+	}
+	self.filters = filters
+	return nil
+}
+
+// interfaceFilter layers a request's filter over the configured one
+func (self *GopsUtil) interfaceFilter(request DeviceFilter) (DeviceFilter, error) {
+	if err := request.Validate(); err != nil {
+		return DeviceFilter{}, err
+	}
+	return DefaultInterfaceFilter.Override(self.filters.Interfaces).Override(request), nil
+}
+
+func (self *GopsUtil) diskFilter(request DeviceFilter) (DeviceFilter, error) {
+	if err := request.Validate(); err != nil {
+		return DeviceFilter{}, err
+	}
+	return DefaultDiskFilter.Override(self.filters.Disks).Override(request), nil
+}
@@ -0,0 +1,67 @@
+package gops
+
+import (
+	"testing"
+
+	"github.com/AvengeMedia/dgop/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultDeviceFilters(t *testing.T) {
+	for _, name := range []string{"wlp2s0", "enp5s0", "enx00e04c680001", "eth0", "wg0", "tailscale0", "usb0", "bond0", "br0", "docker0", "lxcbr0"} {
+		assert.True(t, DefaultInterfaceFilter.Match(name), name)
+	}
+	for _, name := range []string{"lo", "veth3f2a1b0", "ifb0", "sit0", "gre0"} {
+		assert.False(t, DefaultInterfaceFilter.Match(name), name)
+	}
+
+	for _, name := range []string{"sda", "sda1", "nvme0n1", "nvme0n1p2", "vda", "xvda", "hda", "sr0", "mmcblk0", "dm-0", "md127"} {
+		assert.True(t, DefaultDiskFilter.Match(name), name)
+	}
+	for _, name := range []string{"loop0", "zram0", "ram0"} {
+		assert.False(t, DefaultDiskFilter.Match(name), name)
+	}
+}
+
+func TestDeviceFilterOverride(t *testing.T) {
+	gopsUtil := NewGopsUtil()
+	require.NoError(t, gopsUtil.SetDeviceFilters(DeviceFilters{
+		Interfaces: DeviceFilter{Exclude: []string{"lo", "docker*"}},
+	}))
+
+	configured, err := gopsUtil.interfaceFilter(DeviceFilter{})
+	require.NoError(t, err)
+	assert.True(t, configured.Match("veth0"))
+	assert.False(t, configured.Match("docker0"))
+
+	// An include list replaces the whole filter, an exclude list only itself
+	request, err := gopsUtil.interfaceFilter(DeviceFilter{Include: []string{"wl*", "en?"}})
+	require.NoError(t, err)
+	assert.True(t, request.Match("wlan0"))
+	assert.True(t, request.Match("en0"))
+	assert.False(t, request.Match("enp5s0"))
+	assert.False(t, request.Match("docker0"))
+
+	loopback, err := gopsUtil.interfaceFilter(DeviceFilter{Include: []string{"lo"}})
+	require.NoError(t, err)
+	assert.True(t, loopback.Match("lo"))
+
+	disks, err := gopsUtil.diskFilter(DeviceFilter{Exclude: []string{"sd?[0-9]*", "nvme*p*"}})
+	require.NoError(t, err)
+	assert.True(t, disks.Match("sda"))
+	assert.False(t, disks.Match("sda1"))
+	assert.False(t, disks.Match("nvme0n1p1"))
+	assert.False(t, disks.Match("loop0"))
+}
+
+func TestDeviceFilterInvalidPattern(t *testing.T) {
+	gopsUtil := NewGopsUtil()
+
+	_, err := gopsUtil.GetNetworkInfoFiltered(DeviceFilter{Include: []string{"wl[p"}})
+	var customErr *errdefs.CustomError
+	require.ErrorAs(t, err, &customErr)
+	assert.Equal(t, errdefs.ErrTypeInvalidInput, customErr.Type)
+
+	assert.Error(t, gopsUtil.SetDeviceFilters(DeviceFilters{Disks: DeviceFilter{Exclude: []string{"["}}}))
+}
@@ -2,19 +2,28 @@ package gops
 
 import (
 	"fmt"
-	"strings"
+	"slices"
 
 	"github.com/AvengeMedia/dgop/models"
 	"github.com/shirou/gopsutil/v4/disk"
 )
 
 func (self *GopsUtil) GetDiskInfo() ([]*models.DiskInfo, error) {
+	return self.GetDiskInfoFiltered(DeviceFilter{})
+}
+
+// GetDiskInfoFiltered applies filter on top of the configured disk filter
+func (self *GopsUtil) GetDiskInfoFiltered(filter DeviceFilter) ([]*models.DiskInfo, error) {
+	match, err := self.diskFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
 	diskIO, err := disk.IOCounters()
 	res := make([]*models.DiskInfo, 0)
 	if err == nil {
 		for name, d := range diskIO {
-			// Filter to match bash script patterns
-			if matchesDiskDevice(name) {
+			if match.Match(name) {
 				res = append(res, &models.DiskInfo{
 					Name:  name,
 					Read:  d.ReadBytes / 512,  // Convert to sectors
@@ -26,13 +35,23 @@ func (self *GopsUtil) GetDiskInfo() ([]*models.DiskInfo, error) {
 	return res, nil
 }
 
+// DefaultSkippedFSTypes are left out of disk mounts unless asked otherwise
+var DefaultSkippedFSTypes = []string{"tmpfs", "devtmpfs"}
+
 func (self *GopsUtil) GetDiskMounts() ([]*models.DiskMountInfo, error) {
+	return self.GetDiskMountsFiltered(nil)
+}
+
+// GetDiskMountsFiltered skips mounts whose fstype is in skipFSTypes. Empty
+// means DefaultSkippedFSTypes, and "none" skips nothing.
+func (self *GopsUtil) GetDiskMountsFiltered(skipFSTypes []string) ([]*models.DiskMountInfo, error) {
+	skip := skippedFSTypes(skipFSTypes)
+
 	partitions, err := disk.Partitions(false)
 	var metrics []*models.DiskMountInfo
 	if err == nil {
 		for _, p := range partitions {
-			// Skip tmpfs and devtmpfs
-			if p.Fstype == "tmpfs" || p.Fstype == "devtmpfs" {
+			if slices.Contains(skip, p.Fstype) {
 				continue
 			}
 
@@ -41,29 +60,43 @@ func (self *GopsUtil) GetDiskMounts() ([]*models.DiskMountInfo, error) {
 				continue
 			}
 
-			metrics = append(metrics, &models.DiskMountInfo{
-				Device:  p.Device,
-				Mount:   p.Mountpoint,
-				FSType:  p.Fstype,
-				Size:    formatBytes(usage.Total),
-				Used:    formatBytes(usage.Used),
-				Avail:   formatBytes(usage.Free),
-				Percent: fmt.Sprintf("%.0f%%", usage.UsedPercent),
-			})
+			metrics = append(metrics, diskMountInfo(p, usage))
 		}
 	}
 
 	return metrics, nil
 }
 
-func matchesDiskDevice(name string) bool {
-	patterns := []string{"sd", "nvme", "vd", "dm-", "mmcblk"}
-	for _, pattern := range patterns {
-		if strings.HasPrefix(name, pattern) {
-			return true
-		}
+func skippedFSTypes(skipFSTypes []string) []string {
+	switch {
+	case len(skipFSTypes) == 0:
+		return DefaultSkippedFSTypes
+	case len(skipFSTypes) == 1 && skipFSTypes[0] == "none":
+		return nil
+	}
+	return skipFSTypes
+}
+
+func diskMountInfo(p disk.PartitionStat, usage *disk.UsageStat) *models.DiskMountInfo {
+	return &models.DiskMountInfo{
+		Device:            p.Device,
+		Mount:             p.Mountpoint,
+		FSType:            p.Fstype,
+		Size:              formatBytes(usage.Total),
+		Used:              formatBytes(usage.Used),
+		Avail:             formatBytes(usage.Free),
+		Percent:           fmt.Sprintf("%.0f%%", usage.UsedPercent),
+		SizeBytes:         usage.Total,
+		UsedBytes:         usage.Used,
+		AvailBytes:        usage.Free,
+		UsedPercent:       usage.UsedPercent,
+		InodesTotal:       usage.InodesTotal,
+		InodesUsed:        usage.InodesUsed,
+		InodesFree:        usage.InodesFree,
+		InodesUsedPercent: usage.InodesUsedPercent,
+		ReadOnly:          slices.Contains(p.Opts, "ro"),
+		Options:           p.Opts,
 	}
-	return false
 }
 
 func formatBytes(bytes uint64) string {
@@ -0,0 +1,46 @@
+package gops
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/disk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskMountInfo(t *testing.T) {
+	partition := disk.PartitionStat{
+		Device:     "/dev/nvme0n1p2",
+		Mountpoint: "/boot",
+		Fstype:     "vfat",
+		Opts:       []string{"ro", "relatime", "fmask=0022"},
+	}
+	usage := &disk.UsageStat{
+		Total:             1073741824,
+		Used:              268435456,
+		Free:              805306368,
+		UsedPercent:       25,
+		InodesTotal:       1000,
+		InodesUsed:        100,
+		InodesFree:        900,
+		InodesUsedPercent: 10,
+	}
+
+	mount := diskMountInfo(partition, usage)
+	assert.Equal(t, "1.0G", mount.Size)
+	assert.Equal(t, "25%", mount.Percent)
+	assert.Equal(t, uint64(1073741824), mount.SizeBytes)
+	assert.Equal(t, uint64(805306368), mount.AvailBytes)
+	assert.Equal(t, 25.0, mount.UsedPercent)
+	assert.Equal(t, uint64(900), mount.InodesFree)
+	assert.True(t, mount.ReadOnly)
+	assert.Equal(t, []string{"ro", "relatime", "fmask=0022"}, mount.Options)
+
+	partition.Opts = []string{"rw", "errors=remount-ro"}
+	assert.False(t, diskMountInfo(partition, usage).ReadOnly)
+}
+
+func TestSkippedFSTypes(t *testing.T) {
+	assert.Equal(t, DefaultSkippedFSTypes, skippedFSTypes(nil))
+	assert.Empty(t, skippedFSTypes([]string{"none"}))
+	assert.Equal(t, []string{"squashfs"}, skippedFSTypes([]string{"squashfs"}))
+}
@@ -15,6 +15,38 @@ type DiskRateCursor struct {
 }
 
 func (self *GopsUtil) GetDiskRates(cursorStr string) (*models.DiskRateResponse, error) {
+	return self.GetDiskRatesFiltered(cursorStr, DeviceFilter{})
+}
+
+// GetDiskRatesFiltered applies filter on top of the configured disk filter.
+// Cursors carry every device, so the filter can change between calls.
+func (self *GopsUtil) GetDiskRatesFiltered(cursorStr string, filter DeviceFilter) (*models.DiskRateResponse, error) {
+	match, err := self.diskFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, ok := self.sampler.latestDiskRates()
+	if cursorStr != "" || !ok {
+		if rates, err = self.collectDiskRates(cursorStr); err != nil {
+			return nil, err
+		}
+	}
+
+	// Copy rather than filter in place, sampled responses are shared
+	filtered := &models.DiskRateResponse{
+		Disks:  make([]*models.DiskRateInfo, 0, len(rates.Disks)),
+		Cursor: rates.Cursor,
+	}
+	for _, disk := range rates.Disks {
+		if match.Match(disk.Device) {
+			filtered.Disks = append(filtered.Disks, disk)
+		}
+	}
+	return filtered, nil
+}
+
+func (self *GopsUtil) collectDiskRates(cursorStr string) (*models.DiskRateResponse, error) {
 	// Get current disk stats
 	diskIO, err := disk.IOCounters()
 	if err != nil {
@@ -37,18 +69,7 @@ func (self *GopsUtil) GetDiskRates(cursorStr string) (*models.DiskRateResponse,
 			if timeDiff > 0 {
 				for name, current := range currentStats {
 					if prev, exists := cursor.IOStats[name]; exists {
-						readRate := float64(current.ReadBytes-prev.ReadBytes) / timeDiff
-						writeRate := float64(current.WriteBytes-prev.WriteBytes) / timeDiff
-
-						disks = append(disks, &models.DiskRateInfo{
-							Device:     name,
-							ReadRate:   readRate,
-							WriteRate:  writeRate,
-							ReadTotal:  current.ReadBytes,
-							WriteTotal: current.WriteBytes,
-							ReadCount:  current.ReadCount,
-							WriteCount: current.WriteCount,
-						})
+						disks = append(disks, diskRate(name, prev, current, timeDiff))
 					}
 				}
 			}
@@ -66,6 +87,7 @@ func (self *GopsUtil) GetDiskRates(cursorStr string) (*models.DiskRateResponse,
 				WriteTotal: current.WriteBytes,
 				ReadCount:  current.ReadCount,
 				WriteCount: current.WriteCount,
+				InFlight:   current.IopsInProgress,
 			})
 		}
 	}
@@ -87,6 +109,45 @@ func (self *GopsUtil) GetDiskRates(cursorStr string) (*models.DiskRateResponse,
 	}, nil
 }
 
+// diskRate works out the same figures as iostat -x over seconds. The time
+// counters in /proc/diskstats are in milliseconds.
+func diskRate(name string, prev, current disk.IOCountersStat, seconds float64) *models.DiskRateInfo {
+	reads := float64(counterDelta(prev.ReadCount, current.ReadCount))
+	writes := float64(counterDelta(prev.WriteCount, current.WriteCount))
+	elapsedMs := seconds * 1000
+
+	rate := &models.DiskRateInfo{
+		Device:     name,
+		ReadRate:   float64(counterDelta(prev.ReadBytes, current.ReadBytes)) / seconds,
+		WriteRate:  float64(counterDelta(prev.WriteBytes, current.WriteBytes)) / seconds,
+		ReadTotal:  current.ReadBytes,
+		WriteTotal: current.WriteBytes,
+		ReadCount:  current.ReadCount,
+		WriteCount: current.WriteCount,
+		ReadIOPS:   reads / seconds,
+		WriteIOPS:  writes / seconds,
+		Util:       min(float64(counterDelta(prev.IoTime, current.IoTime))/elapsedMs*100, 100),
+		QueueSize:  float64(counterDelta(prev.WeightedIO, current.WeightedIO)) / elapsedMs,
+		InFlight:   current.IopsInProgress,
+	}
+	if reads > 0 {
+		rate.ReadAwait = float64(counterDelta(prev.ReadTime, current.ReadTime)) / reads
+	}
+	if writes > 0 {
+		rate.WriteAwait = float64(counterDelta(prev.WriteTime, current.WriteTime)) / writes
+	}
+	return rate
+}
+
+// counterDelta treats a counter that went backwards, after a device was
+// removed and re-added, as no activity
+func counterDelta(prev, current uint64) uint64 {
+	if current < prev {
+		return 0
+	}
+	return current - prev
+}
+
 func encodeDiskRateCursor(cursor DiskRateCursor) (string, error) {
 	jsonData, err := json.Marshal(cursor)
 	if err != nil {
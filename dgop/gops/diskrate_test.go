@@ -0,0 +1,49 @@
+package gops
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/disk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskRate(t *testing.T) {
+	prev := disk.IOCountersStat{
+		ReadCount: 1000, WriteCount: 500,
+		ReadBytes: 4 << 20, WriteBytes: 2 << 20,
+		ReadTime: 2000, WriteTime: 3000,
+		IoTime: 10000, WeightedIO: 20000,
+	}
+	current := disk.IOCountersStat{
+		ReadCount: 1200, WriteCount: 550,
+		ReadBytes: 8 << 20, WriteBytes: 3 << 20,
+		ReadTime: 2400, WriteTime: 3500,
+		IoTime: 11000, WeightedIO: 21800,
+		IopsInProgress: 3,
+	}
+
+	rate := diskRate("nvme0n1", prev, current, 2)
+	assert.Equal(t, "nvme0n1", rate.Device)
+	assert.InDelta(t, float64(2<<20), rate.ReadRate, 0.001)
+	assert.InDelta(t, float64(512<<10), rate.WriteRate, 0.001)
+	assert.InDelta(t, 100.0, rate.ReadIOPS, 0.001)
+	assert.InDelta(t, 25.0, rate.WriteIOPS, 0.001)
+	assert.InDelta(t, 2.0, rate.ReadAwait, 0.001)
+	assert.InDelta(t, 10.0, rate.WriteAwait, 0.001)
+	assert.InDelta(t, 50.0, rate.Util, 0.001)
+	assert.InDelta(t, 0.9, rate.QueueSize, 0.001)
+	assert.Equal(t, uint64(3), rate.InFlight)
+}
+
+func TestDiskRateIdleAndReset(t *testing.T) {
+	prev := disk.IOCountersStat{ReadCount: 10, ReadTime: 50, IoTime: 5000}
+	// Busy time can overshoot the wall clock slightly, and counters restart
+	// when a device comes back
+	current := disk.IOCountersStat{ReadCount: 5, ReadTime: 20, IoTime: 6100}
+
+	rate := diskRate("sda", prev, current, 1)
+	assert.Zero(t, rate.ReadIOPS)
+	assert.Zero(t, rate.ReadAwait)
+	assert.Zero(t, rate.WriteAwait)
+	assert.Equal(t, 100.0, rate.Util)
+}
@@ -3,10 +3,13 @@ package gops
 import (
 	"github.com/AvengeMedia/dgop/internal/log"
 	"github.com/AvengeMedia/dgop/models"
-	"github.com/shirou/gopsutil/v4/sensors"
 )
 
-type GopsUtil struct{}
+type GopsUtil struct {
+	sampler *Sampler
+	sysRoot string // Tests point this at a fixture tree, empty means /sys
+	filters DeviceFilters
+}
 
 func NewGopsUtil() *GopsUtil {
 	return &GopsUtil{}
@@ -67,24 +70,3 @@ func (self *GopsUtil) GetAllMetricsWithCursors(procSortBy ProcSortBy, procLimit
 		DiskMounts: diskMounts,
 	}, nil
 }
-
-// GetSystemTemperatures returns system temperature sensors
-func (self *GopsUtil) GetSystemTemperatures() ([]models.TemperatureSensor, error) {
-	temps, err := sensors.SensorsTemperatures()
-	if err != nil {
-		return nil, err
-	}
-
-	var sensors []models.TemperatureSensor
-	for _, temp := range temps {
-		sensor := models.TemperatureSensor{
-			Name:        temp.SensorKey,
-			Temperature: temp.Temperature,
-			High:        temp.High,
-			Critical:    temp.Critical,
-		}
-		sensors = append(sensors, sensor)
-	}
-
-	return sensors, nil
-}
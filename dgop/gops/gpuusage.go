@@ -0,0 +1,157 @@
+package gops
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/AvengeMedia/dgop/models"
+)
+
+var (
+	drmCardName = regexp.MustCompile(`^card\d+$`)
+	dpmLevel    = regexp.MustCompile(`(?i)^\d+:\s*(\d+)\s*mhz\s*(\*)?`)
+)
+
+// GetGPUUsage reads utilization, VRAM, clocks and power draw of every GPU
+// from the sysfs of its PCI device, or from nvidia-smi for nvidia cards
+func (self *GopsUtil) GetGPUUsage() (*models.GPUUsageInfo, error) {
+	entries, err := self.detectGPUEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	var nvidia map[string]*nvidiaGPU
+	info := &models.GPUUsageInfo{GPUs: make([]*models.GPUUsage, 0, len(entries))}
+	for _, entry := range entries {
+		usage := self.readGPUUsage(entry.BDF, entry.Driver)
+		if entry.Driver == "nvidia" {
+			// The proprietary driver keeps all of this to nvidia-smi
+			if nvidia == nil {
+				nvidia = self.queryNvidia(entries)
+			}
+			if gpu, ok := nvidia[entry.BDF]; ok {
+				card := usage.Card
+				*usage = gpu.Usage
+				usage.Card = card
+			}
+		}
+		_, usage.PciId = parseGPUInfo(entry.RawLine)
+		info.GPUs = append(info.GPUs, usage)
+	}
+
+	return info, nil
+}
+
+func (self *GopsUtil) readGPUUsage(bdf, driver string) *models.GPUUsage {
+	usage := &models.GPUUsage{BDF: bdf, Driver: driver}
+	device := self.sysPath("bus", "pci", "devices", bdf)
+
+	cards, _ := filepath.Glob(filepath.Join(device, "drm", "card*"))
+	card := ""
+	for _, path := range cards {
+		if drmCardName.MatchString(filepath.Base(path)) {
+			card = path
+			usage.Card = filepath.Base(path)
+			break
+		}
+	}
+
+	switch driver {
+	case "amdgpu":
+		readAMDGPUUsage(device, usage)
+	case "i915":
+		if card != "" {
+			usage.CoreClock = readSysfsIntOr(filepath.Join(card, "gt_act_freq_mhz"))
+			usage.CoreClockMax = readSysfsIntOr(filepath.Join(card, "gt_RP0_freq_mhz"), filepath.Join(card, "gt_max_freq_mhz"))
+		}
+	case "xe":
+		if freqs, _ := filepath.Glob(filepath.Join(device, "tile*", "gt*", "freq0")); len(freqs) > 0 {
+			usage.CoreClock = readSysfsIntOr(filepath.Join(freqs[0], "act_freq"))
+			usage.CoreClockMax = readSysfsIntOr(filepath.Join(freqs[0], "rp0_freq"), filepath.Join(freqs[0], "max_freq"))
+		}
+	}
+
+	readGPUPower(device, usage)
+	return usage
+}
+
+func readAMDGPUUsage(device string, usage *models.GPUUsage) {
+	if busy, ok := readSysfsInt(filepath.Join(device, "gpu_busy_percent")); ok {
+		usage.Utilization = float64(busy)
+	}
+	if busy, ok := readSysfsInt(filepath.Join(device, "mem_busy_percent")); ok {
+		usage.MemoryUtilization = float64(busy)
+	}
+	if used, ok := readSysfsInt(filepath.Join(device, "mem_info_vram_used")); ok {
+		usage.VRAMUsed = uint64(used)
+	}
+	if total, ok := readSysfsInt(filepath.Join(device, "mem_info_vram_total")); ok {
+		usage.VRAMTotal = uint64(total)
+	}
+	usage.CoreClock, usage.CoreClockMax = readDPMClock(filepath.Join(device, "pp_dpm_sclk"))
+	usage.MemoryClock, usage.MemoryClockMax = readDPMClock(filepath.Join(device, "pp_dpm_mclk"))
+}
+
+// readGPUPower reads the board power from the card's hwmon. amdgpu reports
+// power1_average, newer APUs and other drivers power1_input.
+func readGPUPower(device string, usage *models.GPUUsage) {
+	hwmons, _ := filepath.Glob(filepath.Join(device, "hwmon", "hwmon*"))
+	for _, hwmon := range hwmons {
+		power, ok := readSysfsMicro(filepath.Join(hwmon, "power1_average"))
+		if !ok {
+			power, ok = readSysfsMicro(filepath.Join(hwmon, "power1_input"))
+		}
+		powerCap, hasCap := readSysfsMicro(filepath.Join(hwmon, "power1_cap"))
+		if !ok && !hasCap {
+			continue
+		}
+
+		usage.Hwmon = filepath.Base(hwmon)
+		usage.Power = power
+		usage.PowerCap = powerCap
+		return
+	}
+}
+
+// readDPMClock returns the active and highest level of an amdgpu pp_dpm_*
+// table such as "1: 1800Mhz *"
+func readDPMClock(path string) (current, highest int) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		match := dpmLevel.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+		mhz, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		if match[2] != "" {
+			current = mhz
+		}
+		if mhz > highest {
+			highest = mhz
+		}
+	}
+	return current, highest
+}
+
+// readSysfsIntOr reads the first of paths that exists, 0 when none do
+func readSysfsIntOr(paths ...string) int {
+	for _, path := range paths {
+		if v, ok := readSysfsInt(path); ok {
+			return int(v)
+		}
+	}
+	return 0
+}
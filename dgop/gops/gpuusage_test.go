@@ -0,0 +1,63 @@
+package gops
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadGPUUsageAMD(t *testing.T) {
+	root := t.TempDir()
+	writeSysfsAttrs(t, filepath.Join(root, "bus", "pci", "devices", "0000:03:00.0"), map[string]string{
+		"gpu_busy_percent":            "42",
+		"mem_busy_percent":            "7",
+		"mem_info_vram_used":          "1073741824",
+		"mem_info_vram_total":         "17163091968",
+		"pp_dpm_sclk":                 "0: 500Mhz\n1: 1850Mhz *\n2: 2520Mhz",
+		"pp_dpm_mclk":                 "0: 96Mhz *\n1: 1000Mhz",
+		"drm/card1/dev":               "226:1",
+		"drm/renderD128/dev":          "226:128",
+		"hwmon/hwmon4/power1_average": "187000000",
+		"hwmon/hwmon4/power1_cap":     "255000000",
+	})
+
+	usage := (&GopsUtil{sysRoot: root}).readGPUUsage("0000:03:00.0", "amdgpu")
+	assert.Equal(t, "card1", usage.Card)
+	assert.Equal(t, "hwmon4", usage.Hwmon)
+	assert.Equal(t, 42.0, usage.Utilization)
+	assert.Equal(t, 7.0, usage.MemoryUtilization)
+	assert.Equal(t, uint64(1073741824), usage.VRAMUsed)
+	assert.Equal(t, uint64(17163091968), usage.VRAMTotal)
+	assert.Equal(t, 1850, usage.CoreClock)
+	assert.Equal(t, 2520, usage.CoreClockMax)
+	assert.Equal(t, 96, usage.MemoryClock)
+	assert.Equal(t, 1000, usage.MemoryClockMax)
+	assert.InDelta(t, 187.0, usage.Power, 0.001)
+	assert.InDelta(t, 255.0, usage.PowerCap, 0.001)
+}
+
+func TestReadGPUUsageIntel(t *testing.T) {
+	root := t.TempDir()
+	writeSysfsAttrs(t, filepath.Join(root, "bus", "pci", "devices", "0000:00:02.0"), map[string]string{
+		"drm/card0/gt_act_freq_mhz": "650",
+		"drm/card0/gt_max_freq_mhz": "1300",
+		"drm/card0/gt_RP0_freq_mhz": "1450",
+	})
+	writeSysfsAttrs(t, filepath.Join(root, "bus", "pci", "devices", "0000:04:00.0"), map[string]string{
+		"tile0/gt0/freq0/act_freq": "2050",
+		"tile0/gt0/freq0/max_freq": "2400",
+	})
+
+	gopsUtil := &GopsUtil{sysRoot: root}
+
+	i915 := gopsUtil.readGPUUsage("0000:00:02.0", "i915")
+	assert.Equal(t, "card0", i915.Card)
+	assert.Equal(t, 650, i915.CoreClock)
+	assert.Equal(t, 1450, i915.CoreClockMax)
+	assert.Zero(t, i915.Utilization)
+
+	xe := gopsUtil.readGPUUsage("0000:04:00.0", "xe")
+	assert.Equal(t, 2050, xe.CoreClock)
+	assert.Equal(t, 2400, xe.CoreClockMax)
+}
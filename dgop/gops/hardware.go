@@ -3,9 +3,9 @@ package gops
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -51,7 +51,7 @@ func (self *GopsUtil) GetSystemHardware() (*models.SystemHardware, error) {
 }
 
 func (self *GopsUtil) GetGPUInfo() (*models.GPUInfo, error) {
-	gpus, err := detectGPUs()
+	gpus, err := self.detectGPUs()
 	if err != nil {
 		return nil, err
 	}
@@ -60,24 +60,24 @@ func (self *GopsUtil) GetGPUInfo() (*models.GPUInfo, error) {
 }
 
 func (self *GopsUtil) GetGPUInfoWithTemp(pciIds []string) (*models.GPUInfo, error) {
-	gpus, err := detectGPUs()
+	gpuEntries, err := self.detectGPUEntries()
 	if err != nil {
 		return nil, err
 	}
+	gpus := gpusFromEntries(gpuEntries)
 
-	// If PCI IDs are specified, get temperatures for those GPUs
+	// If PCI IDs are specified, get temperatures for those GPUs. Cards are
+	// told apart by BDF so two of the same model each get their own reading.
 	if len(pciIds) > 0 {
-		for i, gpu := range gpus {
-			for _, pciId := range pciIds {
-				if gpu.PciId == pciId {
-					// Get temperature for this specific GPU
-					if tempInfo, err := self.GetGPUTemp(pciId); err == nil {
-						gpus[i].Temperature = tempInfo.Temperature
-						gpus[i].Hwmon = tempInfo.Hwmon
-					}
-					break
-				}
+		var nvidia map[string]*nvidiaGPU
+		for i, entry := range gpuEntries {
+			if !slices.Contains(pciIds, gpus[i].PciId) {
+				continue
+			}
+			if entry.Driver == "nvidia" && nvidia == nil {
+				nvidia = self.queryNvidia(gpuEntries)
 			}
+			gpus[i].Temperature, gpus[i].Hwmon = gpuTemperature(entry, gpus[i].PciId, nvidia)
 		}
 	}
 
@@ -90,7 +90,7 @@ func (self *GopsUtil) GetGPUTemp(pciId string) (*models.GPUTempInfo, error) {
 	}
 
 	// Find the GPU by PCI ID
-	gpuEntries, err := detectGPUEntries()
+	gpuEntries, err := self.detectGPUEntries()
 	if err != nil {
 		return nil, err
 	}
@@ -109,14 +109,11 @@ func (self *GopsUtil) GetGPUTemp(pciId string) (*models.GPUTempInfo, error) {
 	}
 
 	// Auto-detect temperature method based on driver
-	var temperature float64
-	var hwmon string
-
+	var nvidia map[string]*nvidiaGPU
 	if targetGPU.Driver == "nvidia" {
-		temperature, hwmon = getNvidiaTemperature()
-	} else {
-		temperature, hwmon = getHwmonTemperature(pciId)
+		nvidia = self.queryNvidia([]gpuEntry{*targetGPU})
 	}
+	temperature, hwmon := gpuTemperature(*targetGPU, pciId, nvidia)
 
 	return &models.GPUTempInfo{
 		Driver:      targetGPU.Driver,
@@ -190,78 +187,61 @@ func getDistroName() string {
 }
 
 type gpuEntry struct {
-	Priority int
-	Driver   string
-	Vendor   string
-	RawLine  string
+	Priority    int
+	BDF         string
+	Driver      string
+	Vendor      string
+	RawLine     string
+	SubsystemId string
+	Subsystem   string
 }
 
-func detectGPUEntries() ([]gpuEntry, error) {
-	cmd := exec.Command("lspci", "-nnD")
-	output, err := cmd.Output()
+func (self *GopsUtil) detectGPUEntries() ([]gpuEntry, error) {
+	devices, err := self.listDisplayDevices()
 	if err != nil {
 		return nil, err
 	}
 
 	var gpuEntries []gpuEntry
-	vgaRegex := regexp.MustCompile(`(?i) VGA| 3D| 2D| Display`)
-
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		if vgaRegex.MatchString(line) {
-			parts := strings.Fields(line)
-			if len(parts) == 0 {
-				continue
-			}
-
-			bdf := parts[0]
-			driver := getGPUDriver(bdf)
-			vendor := inferVendor(driver, line)
-			priority := getPriority(driver, bdf)
-
-			gpuEntries = append(gpuEntries, gpuEntry{
-				Priority: priority,
-				Driver:   driver,
-				Vendor:   vendor,
-				RawLine:  line,
-			})
+	for _, device := range devices {
+		names := lookupPCINames(device)
+		line := device.lspciLine(names)
+
+		entry := gpuEntry{
+			Priority: getPriority(device.Driver, device.BDF),
+			BDF:      device.BDF,
+			Driver:   device.Driver,
+			Vendor:   inferVendor(device.Driver, line),
+			RawLine:  line,
+		}
+		if device.SubVendor != 0 {
+			entry.SubsystemId = fmt.Sprintf("%04x:%04x", device.SubVendor, device.SubDevice)
+			entry.Subsystem = names.Subsystem
 		}
+		gpuEntries = append(gpuEntries, entry)
 	}
 
 	// Sort by priority (descending), then by driver name
-	sort.Slice(gpuEntries, func(i, j int) bool {
+	sort.SliceStable(gpuEntries, func(i, j int) bool {
 		if gpuEntries[i].Priority != gpuEntries[j].Priority {
 			return gpuEntries[i].Priority > gpuEntries[j].Priority
 		}
 		return gpuEntries[i].Driver < gpuEntries[j].Driver
 	})
 
-	var gpus []models.GPU
-	for _, entry := range gpuEntries {
-		displayName, pciId := parseGPUInfo(entry.RawLine)
-		fullName := buildFullName(entry.Vendor, displayName)
-
-		gpus = append(gpus, models.GPU{
-			Driver:      entry.Driver,
-			Vendor:      entry.Vendor,
-			DisplayName: displayName,
-			FullName:    fullName,
-			PciId:       pciId,
-			RawLine:     entry.RawLine,
-			Temperature: 0,         // TODO: Add GPU temperature detection
-			Hwmon:       "unknown", // TODO: Add hwmon path detection
-		})
-	}
-
 	return gpuEntries, nil
 }
 
-func detectGPUs() ([]models.GPU, error) {
-	gpuEntries, err := detectGPUEntries()
+func (self *GopsUtil) detectGPUs() ([]models.GPU, error) {
+	gpuEntries, err := self.detectGPUEntries()
 	if err != nil {
 		return nil, err
 	}
 
+	return gpusFromEntries(gpuEntries), nil
+}
+
+func gpusFromEntries(gpuEntries []gpuEntry) []models.GPU {
 	var gpus []models.GPU
 	for _, entry := range gpuEntries {
 		displayName, pciId := parseGPUInfo(entry.RawLine)
@@ -273,21 +253,16 @@ func detectGPUs() ([]models.GPU, error) {
 			DisplayName: displayName,
 			FullName:    fullName,
 			PciId:       pciId,
+			BDF:         entry.BDF,
+			SubsystemId: entry.SubsystemId,
+			Subsystem:   entry.Subsystem,
 			RawLine:     entry.RawLine,
 			Temperature: 0,         // TODO: Add GPU temperature detection
 			Hwmon:       "unknown", // TODO: Add hwmon path detection
 		})
 	}
 
-	return gpus, nil
-}
-
-func getGPUDriver(bdf string) string {
-	driverPath := filepath.Join("/sys/bus/pci/devices", bdf, "driver")
-	if link, err := os.Readlink(driverPath); err == nil {
-		return filepath.Base(link)
-	}
-	return ""
+	return gpus
 }
 
 func inferVendor(driver, line string) string {
@@ -417,23 +392,16 @@ func buildFullName(vendor, displayName string) string {
 	}
 }
 
-func getNvidiaTemperature() (float64, string) {
-	// Use nvidia-smi to get GPU temperature
-	cmd := exec.Command("nvidia-smi", "--query-gpu=temperature.gpu", "--format=csv,noheader,nounits")
-	output, err := cmd.Output()
-	if err != nil {
-		return 0, "unknown"
-	}
-
-	tempStr := strings.TrimSpace(string(output))
-	lines := strings.Split(tempStr, "\n")
-	if len(lines) > 0 && lines[0] != "" {
-		if temp, err := strconv.ParseFloat(lines[0], 64); err == nil {
-			return temp, "nvidia"
+// gpuTemperature reads an nvidia card from the nvidia-smi rows by BDF and
+// everything else from hwmon
+func gpuTemperature(entry gpuEntry, pciId string, nvidia map[string]*nvidiaGPU) (float64, string) {
+	if entry.Driver == "nvidia" {
+		if gpu, ok := nvidia[entry.BDF]; ok {
+			return gpu.Temperature, "nvidia"
 		}
+		return 0, "unknown"
 	}
-
-	return 0, "unknown"
+	return getHwmonTemperature(pciId)
 }
 
 func getHwmonTemperature(pciId string) (float64, string) {
@@ -1,6 +1,12 @@
 package gops
 
 import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
 	"github.com/AvengeMedia/dgop/models"
 	"github.com/shirou/gopsutil/v4/mem"
 )
@@ -11,7 +17,7 @@ func (self *GopsUtil) GetMemoryInfo() (*models.MemoryInfo, error) {
 		return nil, err
 	}
 
-	return &models.MemoryInfo{
+	info := &models.MemoryInfo{
 		Total:     v.Total / 1024,
 		Free:      v.Free / 1024,
 		Available: v.Available / 1024,
@@ -20,5 +26,142 @@ func (self *GopsUtil) GetMemoryInfo() (*models.MemoryInfo, error) {
 		Shared:    v.Shared / 1024,
 		SwapTotal: v.SwapTotal / 1024,
 		SwapFree:  v.SwapFree / 1024,
-	}, nil
+	}
+
+	if meminfo, err := readMemInfo("/proc/meminfo"); err == nil {
+		info.Extended = extendedMemory(meminfo)
+		info.Zswap = self.readZswap(meminfo)
+	}
+	info.Zram = self.readZram()
+
+	return info, nil
+}
+
+// readMemInfo maps each /proc/meminfo key to its value, which is in KiB
+// for everything but the HugePages_ counts
+func readMemInfo(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	meminfo := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		if v, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			meminfo[key] = v
+		}
+	}
+	return meminfo, scanner.Err()
+}
+
+func extendedMemory(meminfo map[string]uint64) *models.MemoryExtended {
+	return &models.MemoryExtended{
+		Active:         meminfo["Active"],
+		Inactive:       meminfo["Inactive"],
+		SwapCached:     meminfo["SwapCached"],
+		Dirty:          meminfo["Dirty"],
+		Writeback:      meminfo["Writeback"],
+		AnonPages:      meminfo["AnonPages"],
+		Mapped:         meminfo["Mapped"],
+		Shmem:          meminfo["Shmem"],
+		Slab:           meminfo["Slab"],
+		SReclaimable:   meminfo["SReclaimable"],
+		SUnreclaim:     meminfo["SUnreclaim"],
+		KernelStack:    meminfo["KernelStack"],
+		PageTables:     meminfo["PageTables"],
+		CommitLimit:    meminfo["CommitLimit"],
+		CommittedAS:    meminfo["Committed_AS"],
+		AnonHugePages:  meminfo["AnonHugePages"],
+		HugePagesTotal: meminfo["HugePages_Total"],
+		HugePagesFree:  meminfo["HugePages_Free"],
+		HugePagesRsvd:  meminfo["HugePages_Rsvd"],
+		HugePagesSurp:  meminfo["HugePages_Surp"],
+		HugePageSize:   meminfo["Hugepagesize"],
+	}
+}
+
+// readZram reads every zram device that has been given a size
+func (self *GopsUtil) readZram() []*models.ZramDevice {
+	dirs, _ := filepath.Glob(self.sysPath("block", "zram*"))
+
+	var devices []*models.ZramDevice
+	for _, dir := range dirs {
+		diskSize, ok := readSysfsInt(filepath.Join(dir, "disksize"))
+		if !ok || diskSize == 0 {
+			continue
+		}
+
+		device := &models.ZramDevice{
+			Name:      filepath.Base(dir),
+			Algorithm: selectedOption(readSysfsString(filepath.Join(dir, "comp_algorithm"))),
+			DiskSize:  uint64(diskSize) / 1024,
+		}
+
+		// orig_data_size compr_data_size mem_used_total mem_limit
+		// mem_used_max same_pages pages_compacted huge_pages ...
+		stat := strings.Fields(readSysfsString(filepath.Join(dir, "mm_stat")))
+		field := func(i int) uint64 {
+			if i >= len(stat) {
+				return 0
+			}
+			v, _ := strconv.ParseUint(stat[i], 10, 64)
+			return v
+		}
+		device.OrigDataSize = field(0) / 1024
+		device.ComprDataSize = field(1) / 1024
+		device.MemUsedTotal = field(2) / 1024
+		device.MemLimit = field(3) / 1024
+		device.MemUsedMax = field(4) / 1024
+		device.SamePages = field(5)
+		if compr := field(1); compr > 0 {
+			device.CompressionRatio = float64(field(0)) / float64(compr)
+		}
+
+		devices = append(devices, device)
+	}
+	return devices
+}
+
+// readZswap returns nil when the kernel is built without zswap
+func (self *GopsUtil) readZswap(meminfo map[string]uint64) *models.ZswapInfo {
+	params := self.sysPath("module", "zswap", "parameters")
+	enabled := readSysfsString(filepath.Join(params, "enabled"))
+	if enabled == "" {
+		return nil
+	}
+
+	zswap := &models.ZswapInfo{
+		Enabled:    enabled == "Y" || enabled == "1",
+		Compressor: readSysfsString(filepath.Join(params, "compressor")),
+		Zpool:      readSysfsString(filepath.Join(params, "zpool")),
+		PoolSize:   meminfo["Zswap"],
+		StoredSize: meminfo["Zswapped"],
+	}
+	if percent, ok := readSysfsInt(filepath.Join(params, "max_pool_percent")); ok {
+		zswap.MaxPoolPercent = int(percent)
+	}
+	if zswap.PoolSize > 0 {
+		zswap.CompressionRatio = float64(zswap.StoredSize) / float64(zswap.PoolSize)
+	}
+	return zswap
+}
+
+// selectedOption picks the bracketed choice out of "lzo [lz4] zstd"
+func selectedOption(options string) string {
+	start := strings.Index(options, "[")
+	end := strings.Index(options, "]")
+	if start < 0 || end < start {
+		return options
+	}
+	return options[start+1 : end]
 }
@@ -0,0 +1,78 @@
+package gops
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMemInfo = `MemTotal:       32594344 kB
+MemFree:         1245308 kB
+Dirty:              9876 kB
+Writeback:             4 kB
+Slab:             812344 kB
+SReclaimable:     602112 kB
+Committed_AS:   25436712 kB
+CommitLimit:    32751320 kB
+Zswap:            262144 kB
+Zswapped:         917504 kB
+HugePages_Total:       8
+Hugepagesize:       2048 kB
+`
+
+func TestReadMemInfo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meminfo")
+	writeFixture(t, path, testMemInfo)
+
+	meminfo, err := readMemInfo(path)
+	require.NoError(t, err)
+
+	extended := extendedMemory(meminfo)
+	assert.Equal(t, uint64(9876), extended.Dirty)
+	assert.Equal(t, uint64(602112), extended.SReclaimable)
+	assert.Equal(t, uint64(25436712), extended.CommittedAS)
+	assert.Equal(t, uint64(8), extended.HugePagesTotal)
+	assert.Equal(t, uint64(2048), extended.HugePageSize)
+	assert.Zero(t, extended.Mapped)
+
+	root := t.TempDir()
+	params := filepath.Join(root, "module", "zswap", "parameters")
+	writeFixture(t, filepath.Join(params, "enabled"), "Y\n")
+	writeFixture(t, filepath.Join(params, "compressor"), "zstd\n")
+	writeFixture(t, filepath.Join(params, "max_pool_percent"), "20\n")
+
+	zswap := (&GopsUtil{sysRoot: root}).readZswap(meminfo)
+	require.NotNil(t, zswap)
+	assert.True(t, zswap.Enabled)
+	assert.Equal(t, "zstd", zswap.Compressor)
+	assert.Equal(t, 20, zswap.MaxPoolPercent)
+	assert.Equal(t, uint64(262144), zswap.PoolSize)
+	assert.InDelta(t, 3.5, zswap.CompressionRatio, 0.001)
+
+	assert.Nil(t, (&GopsUtil{sysRoot: t.TempDir()}).readZswap(meminfo))
+}
+
+func TestReadZram(t *testing.T) {
+	root := t.TempDir()
+	zram0 := filepath.Join(root, "block", "zram0")
+	writeFixture(t, filepath.Join(zram0, "disksize"), "8589934592\n")
+	writeFixture(t, filepath.Join(zram0, "comp_algorithm"), "lzo lzo-rle lz4 [zstd]\n")
+	writeFixture(t, filepath.Join(zram0, "mm_stat"), "1073741824 268435456 285212672        0 301989888     1024        0        3        3\n")
+	// Not set up yet
+	writeFixture(t, filepath.Join(root, "block", "zram1", "disksize"), "0\n")
+
+	devices := (&GopsUtil{sysRoot: root}).readZram()
+	require.Len(t, devices, 1)
+
+	zram := devices[0]
+	assert.Equal(t, "zram0", zram.Name)
+	assert.Equal(t, "zstd", zram.Algorithm)
+	assert.Equal(t, uint64(8*1024*1024), zram.DiskSize)
+	assert.Equal(t, uint64(1024*1024), zram.OrigDataSize)
+	assert.Equal(t, uint64(256*1024), zram.ComprDataSize)
+	assert.Equal(t, uint64(272*1024), zram.MemUsedTotal)
+	assert.Equal(t, uint64(1024), zram.SamePages)
+	assert.InDelta(t, 4.0, zram.CompressionRatio, 0.001)
+}
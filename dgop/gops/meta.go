@@ -15,11 +15,17 @@ var availableModules = []string{
 	"disk",
 	"disk-rate",
 	"diskmounts",
+	"blockdevices",
 	"processes",
 	"system",
 	"hardware",
 	"gpu",
 	"gpu-temp",
+	"gpu-usage",
+	"connections",
+	"power",
+	"sensors",
+	"temperatures",
 }
 
 func (self *GopsUtil) GetModules() (*models.ModulesInfo, error) {
@@ -37,9 +43,27 @@ type MetaParams struct {
 	ProcCursor     string
 	NetRateCursor  string
 	DiskRateCursor string
+	ProcFilter     ProcessFilter
+	ListeningOnly  bool
+	PowerCursor    string
+	SkipFSTypes    []string
+	SMART          bool
+	NetFilter      DeviceFilter
+	DiskFilter     DeviceFilter
 }
 
 func (self *GopsUtil) GetMeta(modules []string, params MetaParams) (*models.MetaInfo, error) {
+	// Module errors are swallowed below, so reject a bad filter up front
+	if _, err := params.ProcFilter.matcher(); err != nil {
+		return nil, err
+	}
+	if err := params.NetFilter.Validate(); err != nil {
+		return nil, err
+	}
+	if err := params.DiskFilter.Validate(); err != nil {
+		return nil, err
+	}
+
 	meta := &models.MetaInfo{}
 
 	for _, module := range modules {
@@ -56,28 +80,33 @@ func (self *GopsUtil) GetMeta(modules []string, params MetaParams) (*models.Meta
 				meta.Memory = mem
 			}
 		case "network":
-			if net, err := self.GetNetworkInfo(); err == nil {
+			if net, err := self.GetNetworkInfoFiltered(params.NetFilter); err == nil {
 				meta.Network = net
 			}
 		case "net-rate":
-			if netRate, err := self.GetNetworkRates(params.NetRateCursor); err == nil {
+			if netRate, err := self.GetNetworkRatesFiltered(params.NetRateCursor, params.NetFilter); err == nil {
 				meta.NetRate = netRate
 			}
 		case "disk":
-			if disk, err := self.GetDiskInfo(); err == nil {
+			if disk, err := self.GetDiskInfoFiltered(params.DiskFilter); err == nil {
 				meta.Disk = disk
 			}
 		case "disk-rate":
-			if diskRate, err := self.GetDiskRates(params.DiskRateCursor); err == nil {
+			if diskRate, err := self.GetDiskRatesFiltered(params.DiskRateCursor, params.DiskFilter); err == nil {
 				meta.DiskRate = diskRate
 			}
 		case "diskmounts":
-			if mounts, err := self.GetDiskMounts(); err == nil {
+			if mounts, err := self.GetDiskMountsFiltered(params.SkipFSTypes); err == nil {
 				meta.DiskMounts = mounts
 			}
+		case "blockdevices":
+			if devices, err := self.GetBlockDevices(params.SMART); err == nil {
+				meta.BlockDevices = devices
+			}
 		case "processes":
-			if result, err := self.GetProcessesWithCursor(params.SortBy, params.ProcLimit, params.EnableCPU, params.ProcCursor); err == nil {
+			if result, err := self.GetFilteredProcesses(params.SortBy, params.ProcLimit, params.EnableCPU, params.ProcCursor, params.ProcFilter); err == nil {
 				meta.Processes = result.Processes
+				meta.ProcCursor = result.Cursor
 			}
 		case "system":
 			if sys, err := self.GetSystemInfo(); err == nil {
@@ -97,6 +126,26 @@ func (self *GopsUtil) GetMeta(modules []string, params MetaParams) (*models.Meta
 			if gpu, err := self.GetGPUInfoWithTemp(params.GPUPciIds); err == nil {
 				meta.GPU = gpu
 			}
+		case "gpu-usage":
+			if usage, err := self.GetGPUUsage(); err == nil {
+				meta.GPUUsage = usage
+			}
+		case "power":
+			if power, err := self.GetPowerInfo(params.PowerCursor); err == nil {
+				meta.Power = power
+			}
+		case "sensors":
+			if sensors, err := self.GetSensors(); err == nil {
+				meta.Sensors = sensors
+			}
+		case "temperatures":
+			if temps, err := self.GetTemperatures(); err == nil {
+				meta.Temperatures = temps
+			}
+		case "connections":
+			if conns, err := self.GetConnections(nil, params.ListeningOnly); err == nil {
+				meta.Connections = conns
+			}
 		default:
 			return nil, fmt.Errorf("unknown module: %s", module)
 		}
@@ -105,6 +154,8 @@ func (self *GopsUtil) GetMeta(modules []string, params MetaParams) (*models.Meta
 	return meta, nil
 }
 
+// loadAllModules leaves out connections, which walks the fds of every
+// process, and temperatures, which sensors already covers
 func (self *GopsUtil) loadAllModules(params MetaParams) (*models.MetaInfo, error) {
 	meta := &models.MetaInfo{}
 
@@ -117,28 +168,33 @@ func (self *GopsUtil) loadAllModules(params MetaParams) (*models.MetaInfo, error
 		meta.Memory = mem
 	}
 
-	if net, err := self.GetNetworkInfo(); err == nil {
+	if net, err := self.GetNetworkInfoFiltered(params.NetFilter); err == nil {
 		meta.Network = net
 	}
 
-	if netRate, err := self.GetNetworkRates(params.NetRateCursor); err == nil {
+	if netRate, err := self.GetNetworkRatesFiltered(params.NetRateCursor, params.NetFilter); err == nil {
 		meta.NetRate = netRate
 	}
 
-	if disk, err := self.GetDiskInfo(); err == nil {
+	if disk, err := self.GetDiskInfoFiltered(params.DiskFilter); err == nil {
 		meta.Disk = disk
 	}
 
-	if diskRate, err := self.GetDiskRates(params.DiskRateCursor); err == nil {
+	if diskRate, err := self.GetDiskRatesFiltered(params.DiskRateCursor, params.DiskFilter); err == nil {
 		meta.DiskRate = diskRate
 	}
 
-	if mounts, err := self.GetDiskMounts(); err == nil {
+	if mounts, err := self.GetDiskMountsFiltered(params.SkipFSTypes); err == nil {
 		meta.DiskMounts = mounts
 	}
 
-	if result, err := self.GetProcessesWithCursor(params.SortBy, params.ProcLimit, params.EnableCPU, params.ProcCursor); err == nil {
+	if devices, err := self.GetBlockDevices(params.SMART); err == nil {
+		meta.BlockDevices = devices
+	}
+
+	if result, err := self.GetFilteredProcesses(params.SortBy, params.ProcLimit, params.EnableCPU, params.ProcCursor, params.ProcFilter); err == nil {
 		meta.Processes = result.Processes
+		meta.ProcCursor = result.Cursor
 	}
 
 	if sys, err := self.GetSystemInfo(); err == nil {
@@ -153,5 +209,17 @@ func (self *GopsUtil) loadAllModules(params MetaParams) (*models.MetaInfo, error
 		meta.GPU = gpu
 	}
 
+	if usage, err := self.GetGPUUsage(); err == nil {
+		meta.GPUUsage = usage
+	}
+
+	if power, err := self.GetPowerInfo(params.PowerCursor); err == nil {
+		meta.Power = power
+	}
+
+	if sensors, err := self.GetSensors(); err == nil {
+		meta.Sensors = sensors
+	}
+
 	return meta, nil
 }
@@ -1,6 +1,11 @@
 package gops
 
 import (
+	"bufio"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/AvengeMedia/dgop/models"
@@ -8,29 +13,168 @@ import (
 )
 
 func (self *GopsUtil) GetNetworkInfo() ([]*models.NetworkInfo, error) {
+	return self.GetNetworkInfoFiltered(DeviceFilter{})
+}
+
+// GetNetworkInfoFiltered applies filter on top of the configured interface filter
+func (self *GopsUtil) GetNetworkInfoFiltered(filter DeviceFilter) ([]*models.NetworkInfo, error) {
+	match, err := self.interfaceFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
 	netIO, err := net.IOCounters(true)
 	res := make([]*models.NetworkInfo, 0)
 	if err == nil {
+		addrs := interfaceAddresses()
+		wireless, _ := readProcNetWireless("/proc/net/wireless")
+
 		for _, n := range netIO {
-			// Filter to match bash script (wlan, wlo, wlp, eth, eno, enp, ens, lxc)
-			if matchesNetworkInterface(n.Name) {
-				res = append(res, &models.NetworkInfo{
-					Name: n.Name,
-					Rx:   n.BytesRecv,
-					Tx:   n.BytesSent,
-				})
+			if match.Match(n.Name) {
+				info := self.readNetInterface(n.Name)
+				info.Rx = n.BytesRecv
+				info.Tx = n.BytesSent
+				if a, ok := addrs[n.Name]; ok {
+					info.Addresses = a
+				}
+				info.Wireless = wireless[n.Name]
+				res = append(res, info)
 			}
 		}
 	}
 	return res, nil
 }
 
-func matchesNetworkInterface(name string) bool {
-	prefixes := []string{"wlan", "wlo", "wlp", "eth", "eno", "enp", "ens", "lxc"}
-	for _, prefix := range prefixes {
-		if strings.HasPrefix(name, prefix) {
-			return true
+// readNetInterface fills in what /sys/class/net/<name> knows about a link
+func (self *GopsUtil) readNetInterface(name string) *models.NetworkInfo {
+	dir := self.sysPath("class", "net", name)
+
+	info := &models.NetworkInfo{
+		Name:      name,
+		Type:      netInterfaceType(dir, name),
+		MAC:       readSysfsString(filepath.Join(dir, "address")),
+		OperState: readSysfsString(filepath.Join(dir, "operstate")),
+		// carrier can't be read while the interface is down
+		Carrier:   readSysfsString(filepath.Join(dir, "carrier")) == "1",
+		Addresses: make([]*models.NetworkAddress, 0),
+	}
+	if info.MAC == "00:00:00:00:00:00" {
+		info.MAC = ""
+	}
+	if mtu, ok := readSysfsInt(filepath.Join(dir, "mtu")); ok {
+		info.MTU = int(mtu)
+	}
+	// Virtual links and links without carrier report -1 and unknown
+	if speed, ok := readSysfsInt(filepath.Join(dir, "speed")); ok && speed > 0 {
+		info.Speed = int(speed)
+	}
+	if duplex := readSysfsString(filepath.Join(dir, "duplex")); duplex != "unknown" {
+		info.Duplex = duplex
+	}
+	if driver, err := filepath.EvalSymlinks(filepath.Join(dir, "device", "driver")); err == nil {
+		info.Driver = filepath.Base(driver)
+	}
+
+	return info
+}
+
+// netInterfaceType classifies a link from its sysfs entries
+func netInterfaceType(dir, name string) string {
+	exists := func(elem string) bool {
+		_, err := os.Stat(filepath.Join(dir, elem))
+		return err == nil
+	}
+
+	// ARPHRD_LOOPBACK
+	if name == "lo" || readSysfsString(filepath.Join(dir, "type")) == "772" {
+		return "loopback"
+	}
+	if exists("wireless") || exists("phy80211") {
+		return "wireless"
+	}
+	if exists("bridge") {
+		return "bridge"
+	}
+	if exists("tun_flags") || strings.Contains(readSysfsString(filepath.Join(dir, "uevent")), "DEVTYPE=wireguard") {
+		return "vpn"
+	}
+	if exists("device") {
+		return "physical"
+	}
+	return "virtual"
+}
+
+// interfaceAddresses maps interface names to their IPv4 and IPv6 addresses
+func interfaceAddresses() map[string][]*models.NetworkAddress {
+	result := make(map[string][]*models.NetworkAddress)
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return result
+	}
+
+	for _, iface := range interfaces {
+		addrs := make([]*models.NetworkAddress, 0, len(iface.Addrs))
+		for _, addr := range iface.Addrs {
+			if a := parseInterfaceAddr(addr.Addr); a != nil {
+				addrs = append(addrs, a)
+			}
+		}
+		result[iface.Name] = addrs
+	}
+	return result
+}
+
+// parseInterfaceAddr parses CIDR notation such as 192.168.1.20/24
+func parseInterfaceAddr(cidr string) *models.NetworkAddress {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil
+	}
+
+	family := "ipv4"
+	if prefix.Addr().Is6() && !prefix.Addr().Is4In6() {
+		family = "ipv6"
+	}
+	return &models.NetworkAddress{
+		Address: prefix.Addr().Unmap().String(),
+		Prefix:  prefix.Bits(),
+		Family:  family,
+	}
+}
+
+// readProcNetWireless parses /proc/net/wireless, whose two header lines
+// are followed by one line per interface:
+//
+//	wlan0: 0000   70.  -40.  -256        0      0      0      0      0        0
+func readProcNetWireless(path string) (map[string]*models.WirelessInfo, error) {
+	result := make(map[string]*models.WirelessInfo)
+	file, err := os.Open(path)
+	if err != nil {
+		return result, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		name, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 4 {
+			continue
+		}
+
+		// Values carry a trailing dot when they were updated since the last read
+		value := func(s string) float64 {
+			v, _ := strconv.ParseFloat(strings.TrimRight(s, "."), 64)
+			return v
+		}
+		result[strings.TrimSpace(name)] = &models.WirelessInfo{
+			LinkQuality: value(fields[1]),
+			SignalLevel: value(fields[2]),
+			NoiseLevel:  value(fields[3]),
 		}
 	}
-	return false
+	return result, scanner.Err()
 }
@@ -0,0 +1,111 @@
+package gops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadNetInterface(t *testing.T) {
+	root := t.TempDir()
+	eth := filepath.Join(root, "class", "net", "enp5s0")
+	writeSysfsAttrs(t, eth, map[string]string{
+		"address":   "a8:a1:59:12:34:56",
+		"mtu":       "1500",
+		"operstate": "up",
+		"carrier":   "1",
+		"speed":     "2500",
+		"duplex":    "full",
+		"type":      "1",
+	})
+	driver := filepath.Join(root, "bus", "pci", "drivers", "igc")
+	require.NoError(t, os.MkdirAll(driver, 0o755))
+	pciDev := filepath.Join(root, "devices", "pci0000:00", "0000:05:00.0")
+	require.NoError(t, os.MkdirAll(pciDev, 0o755))
+	require.NoError(t, os.Symlink(driver, filepath.Join(pciDev, "driver")))
+	require.NoError(t, os.Symlink(pciDev, filepath.Join(eth, "device")))
+
+	gopsUtil := &GopsUtil{sysRoot: root}
+	info := gopsUtil.readNetInterface("enp5s0")
+	assert.Equal(t, "physical", info.Type)
+	assert.Equal(t, "a8:a1:59:12:34:56", info.MAC)
+	assert.Equal(t, 1500, info.MTU)
+	assert.Equal(t, "up", info.OperState)
+	assert.True(t, info.Carrier)
+	assert.Equal(t, 2500, info.Speed)
+	assert.Equal(t, "full", info.Duplex)
+	assert.Equal(t, "igc", info.Driver)
+	assert.NotNil(t, info.Addresses)
+
+	writeSysfsAttrs(t, filepath.Join(root, "class", "net", "wg0"), map[string]string{
+		"operstate": "unknown",
+		"speed":     "-1",
+		"duplex":    "unknown",
+		"type":      "65534",
+		"uevent":    "DEVTYPE=wireguard\nINTERFACE=wg0\nIFINDEX=7",
+	})
+	wg := gopsUtil.readNetInterface("wg0")
+	assert.Equal(t, "vpn", wg.Type)
+	assert.Zero(t, wg.Speed)
+	assert.Empty(t, wg.Duplex)
+	assert.False(t, wg.Carrier)
+}
+
+func TestNetInterfaceType(t *testing.T) {
+	root := t.TempDir()
+	cases := map[string]struct {
+		entries []string
+		want    string
+	}{
+		"lo":      {entries: []string{"type"}, want: "loopback"},
+		"wlp2s0":  {entries: []string{"device/vendor", "wireless/.keep", "phy80211/name"}, want: "wireless"},
+		"br0":     {entries: []string{"bridge/stp_state"}, want: "bridge"},
+		"tun0":    {entries: []string{"tun_flags"}, want: "vpn"},
+		"veth1a2": {entries: []string{"type"}, want: "virtual"},
+	}
+	for name, tc := range cases {
+		attrs := make(map[string]string)
+		for _, entry := range tc.entries {
+			attrs[entry] = ""
+		}
+		dir := filepath.Join(root, "class", "net", name)
+		writeSysfsAttrs(t, dir, attrs)
+		assert.Equal(t, tc.want, netInterfaceType(dir, name), name)
+	}
+}
+
+func TestParseInterfaceAddr(t *testing.T) {
+	v4 := parseInterfaceAddr("192.168.1.20/24")
+	require.NotNil(t, v4)
+	assert.Equal(t, "192.168.1.20", v4.Address)
+	assert.Equal(t, 24, v4.Prefix)
+	assert.Equal(t, "ipv4", v4.Family)
+
+	v6 := parseInterfaceAddr("fe80::1c2f:9aff:fe01:2345/64")
+	require.NotNil(t, v6)
+	assert.Equal(t, "ipv6", v6.Family)
+	assert.Equal(t, 64, v6.Prefix)
+
+	assert.Nil(t, parseInterfaceAddr("not an address"))
+}
+
+func TestReadProcNetWireless(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wireless")
+	writeFixture(t, path, ""+
+		"Inter-| sta-|   Quality        |   Discarded packets               | Missed | WE\n"+
+		" face | tus | link level noise |  nwid  crypt   frag  retry   misc | beacon | 22\n"+
+		"wlp2s0: 0000   58.  -52.  -256        0      0      0     12      4        0\n")
+
+	wireless, err := readProcNetWireless(path)
+	require.NoError(t, err)
+	require.Len(t, wireless, 1)
+	assert.Equal(t, 58.0, wireless["wlp2s0"].LinkQuality)
+	assert.Equal(t, -52.0, wireless["wlp2s0"].SignalLevel)
+	assert.Equal(t, -256.0, wireless["wlp2s0"].NoiseLevel)
+
+	_, err = readProcNetWireless(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
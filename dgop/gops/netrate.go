@@ -15,6 +15,38 @@ type NetworkRateCursor struct {
 }
 
 func (self *GopsUtil) GetNetworkRates(cursorStr string) (*models.NetworkRateResponse, error) {
+	return self.GetNetworkRatesFiltered(cursorStr, DeviceFilter{})
+}
+
+// GetNetworkRatesFiltered applies filter on top of the configured interface
+// filter. Cursors carry every interface, so the filter can change between calls.
+func (self *GopsUtil) GetNetworkRatesFiltered(cursorStr string, filter DeviceFilter) (*models.NetworkRateResponse, error) {
+	match, err := self.interfaceFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, ok := self.sampler.latestNetworkRates()
+	if cursorStr != "" || !ok {
+		if rates, err = self.collectNetworkRates(cursorStr); err != nil {
+			return nil, err
+		}
+	}
+
+	// Copy rather than filter in place, sampled responses are shared
+	filtered := &models.NetworkRateResponse{
+		Interfaces: make([]*models.NetworkRateInfo, 0, len(rates.Interfaces)),
+		Cursor:     rates.Cursor,
+	}
+	for _, iface := range rates.Interfaces {
+		if match.Match(iface.Interface) {
+			filtered.Interfaces = append(filtered.Interfaces, iface)
+		}
+	}
+	return filtered, nil
+}
+
+func (self *GopsUtil) collectNetworkRates(cursorStr string) (*models.NetworkRateResponse, error) {
 	// Get current network stats
 	netIO, err := net.IOCounters(true)
 	if err != nil {
@@ -23,9 +55,7 @@ func (self *GopsUtil) GetNetworkRates(cursorStr string) (*models.NetworkRateResp
 
 	currentStats := make(map[string]net.IOCountersStat)
 	for _, n := range netIO {
-		if matchesNetworkInterface(n.Name) {
-			currentStats[n.Name] = n
-		}
+		currentStats[n.Name] = n
 	}
 
 	currentTime := time.Now()
@@ -39,16 +69,7 @@ func (self *GopsUtil) GetNetworkRates(cursorStr string) (*models.NetworkRateResp
 			if timeDiff > 0 {
 				for name, current := range currentStats {
 					if prev, exists := cursor.IOStats[name]; exists {
-						rxRate := float64(current.BytesRecv-prev.BytesRecv) / timeDiff
-						txRate := float64(current.BytesSent-prev.BytesSent) / timeDiff
-
-						interfaces = append(interfaces, &models.NetworkRateInfo{
-							Interface: name,
-							RxRate:    rxRate,
-							TxRate:    txRate,
-							RxTotal:   current.BytesRecv,
-							TxTotal:   current.BytesSent,
-						})
+						interfaces = append(interfaces, networkRate(name, prev, current, timeDiff))
 					}
 				}
 			}
@@ -85,6 +106,26 @@ func (self *GopsUtil) GetNetworkRates(cursorStr string) (*models.NetworkRateResp
 	}, nil
 }
 
+func networkRate(name string, prev, current net.IOCountersStat, seconds float64) *models.NetworkRateInfo {
+	rate := func(prev, current uint64) float64 {
+		return float64(counterDelta(prev, current)) / seconds
+	}
+
+	return &models.NetworkRateInfo{
+		Interface:    name,
+		RxRate:       rate(prev.BytesRecv, current.BytesRecv),
+		TxRate:       rate(prev.BytesSent, current.BytesSent),
+		RxTotal:      current.BytesRecv,
+		TxTotal:      current.BytesSent,
+		RxPacketRate: rate(prev.PacketsRecv, current.PacketsRecv),
+		TxPacketRate: rate(prev.PacketsSent, current.PacketsSent),
+		RxErrorRate:  rate(prev.Errin, current.Errin),
+		TxErrorRate:  rate(prev.Errout, current.Errout),
+		RxDropRate:   rate(prev.Dropin, current.Dropin),
+		TxDropRate:   rate(prev.Dropout, current.Dropout),
+	}
+}
+
 func encodeNetworkRateCursor(cursor NetworkRateCursor) (string, error) {
 	jsonData, err := json.Marshal(cursor)
 	if err != nil {
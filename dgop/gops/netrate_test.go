@@ -0,0 +1,38 @@
+package gops
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkRate(t *testing.T) {
+	prev := net.IOCountersStat{
+		BytesRecv: 1000, BytesSent: 500,
+		PacketsRecv: 100, PacketsSent: 50,
+		Errin: 2, Dropin: 10,
+	}
+	current := net.IOCountersStat{
+		BytesRecv: 5000, BytesSent: 1500,
+		PacketsRecv: 140, PacketsSent: 60,
+		Errin: 8, Errout: 2, Dropin: 14,
+	}
+
+	rate := networkRate("wlp2s0", prev, current, 2)
+	assert.Equal(t, "wlp2s0", rate.Interface)
+	assert.InDelta(t, 2000.0, rate.RxRate, 0.001)
+	assert.InDelta(t, 500.0, rate.TxRate, 0.001)
+	assert.Equal(t, uint64(5000), rate.RxTotal)
+	assert.InDelta(t, 20.0, rate.RxPacketRate, 0.001)
+	assert.InDelta(t, 5.0, rate.TxPacketRate, 0.001)
+	assert.InDelta(t, 3.0, rate.RxErrorRate, 0.001)
+	assert.InDelta(t, 1.0, rate.TxErrorRate, 0.001)
+	assert.InDelta(t, 2.0, rate.RxDropRate, 0.001)
+	assert.Zero(t, rate.TxDropRate)
+
+	// Counters start over when an interface is recreated
+	reset := networkRate("wlp2s0", current, prev, 2)
+	assert.Zero(t, reset.RxRate)
+	assert.Zero(t, reset.RxErrorRate)
+}
@@ -0,0 +1,140 @@
+package gops
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AvengeMedia/dgop/models"
+)
+
+// nvidiaQueryFields are read in this order from each nvidia-smi row
+var nvidiaQueryFields = []string{
+	"pci.bus_id",
+	"temperature.gpu",
+	"utilization.gpu",
+	"utilization.memory",
+	"memory.used",
+	"memory.total",
+	"power.draw",
+	"power.limit",
+	"clocks.gr",
+	"clocks.max.gr",
+	"clocks.mem",
+	"clocks.max.mem",
+}
+
+type nvidiaGPU struct {
+	Temperature float64
+	Usage       models.GPUUsage
+}
+
+// nvidiaSMITimeout bounds an nvidia-smi run, a wedged driver can hang it
+var nvidiaSMITimeout = 5 * time.Second
+
+// queryNvidiaSMI asks nvidia-smi about every card at once, keyed by the BDF
+// in the same form lspci prints it
+func queryNvidiaSMI() (map[string]*nvidiaGPU, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), nvidiaSMITimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "nvidia-smi",
+		"--query-gpu="+strings.Join(nvidiaQueryFields, ","),
+		"--format=csv,noheader,nounits")
+	cmd.WaitDelay = time.Second
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseNvidiaSMI(string(output)), nil
+}
+
+// queryNvidia reads the nvidia cards among entries that are awake, a missing
+// or failing nvidia-smi gives no readings. nvidia-smi wakes a runtime
+// suspended card, which drains a laptop's battery when done on every poll,
+// so it isn't run while every card sleeps and suspended cards get no reading.
+func (self *GopsUtil) queryNvidia(entries []gpuEntry) map[string]*nvidiaGPU {
+	gpus := make(map[string]*nvidiaGPU)
+
+	var awake []string
+	for _, entry := range entries {
+		if entry.Driver == "nvidia" && !self.pciSuspended(entry.BDF) {
+			awake = append(awake, entry.BDF)
+		}
+	}
+	if len(awake) == 0 {
+		return gpus
+	}
+
+	all, err := queryNvidiaSMI()
+	if err != nil {
+		return gpus
+	}
+	for _, bdf := range awake {
+		if gpu, ok := all[bdf]; ok {
+			gpus[bdf] = gpu
+		}
+	}
+	return gpus
+}
+
+func parseNvidiaSMI(output string) map[string]*nvidiaGPU {
+	gpus := make(map[string]*nvidiaGPU)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != len(nvidiaQueryFields) {
+			continue
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		bdf, ok := normalizeNvidiaBusID(fields[0])
+		if !ok {
+			continue
+		}
+
+		// Unsupported fields read [N/A] or [Not Supported] and stay zero
+		num := func(i int) float64 {
+			v, _ := strconv.ParseFloat(fields[i], 64)
+			return v
+		}
+		const mib = 1024 * 1024
+
+		gpus[bdf] = &nvidiaGPU{
+			Temperature: num(1),
+			Usage: models.GPUUsage{
+				BDF:               bdf,
+				Driver:            "nvidia",
+				Utilization:       num(2),
+				MemoryUtilization: num(3),
+				VRAMUsed:          uint64(num(4) * mib),
+				VRAMTotal:         uint64(num(5) * mib),
+				Power:             num(6),
+				PowerCap:          num(7),
+				CoreClock:         int(num(8)),
+				CoreClockMax:      int(num(9)),
+				MemoryClock:       int(num(10)),
+				MemoryClockMax:    int(num(11)),
+			},
+		}
+	}
+	return gpus
+}
+
+// normalizeNvidiaBusID turns 00000000:01:00.0 into 0000:01:00.0
+func normalizeNvidiaBusID(busID string) (string, bool) {
+	domain, rest, ok := strings.Cut(strings.ToLower(busID), ":")
+	if !ok {
+		return "", false
+	}
+	n, err := strconv.ParseUint(domain, 16, 32)
+	if err != nil || n > 0xffff {
+		return "", false
+	}
+	return fmt.Sprintf("%04x:%s", n, rest), true
+}
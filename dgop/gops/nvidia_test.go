@@ -0,0 +1,130 @@
+package gops
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCommand puts a name on PATH that runs script with sh
+func fakeCommand(t *testing.T, name, script string) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0o755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// printAndExit is a fakeCommand script that prints output and exits with code
+func printAndExit(output string, code int) string {
+	return "cat <<'EOF'\n" + output + "EOF\nexit " + strconv.Itoa(code) + "\n"
+}
+
+func fakeNvidiaSMI(t *testing.T, output string) {
+	fakeCommand(t, "nvidia-smi", printAndExit(output, 0))
+}
+
+func TestQueryNvidiaSMI(t *testing.T) {
+	fakeNvidiaSMI(t, ""+
+		"00000000:01:00.0, 64, 97, 41, 20480, 24564, 312.45, 450.00, 2730, 3105, 10501, 10501\n"+
+		"00000000:41:00.0, 38, 0, 0, 1, 24564, [N/A], [Not Supported], 210, 3105, 405, 10501\n")
+
+	gpus, err := queryNvidiaSMI()
+	require.NoError(t, err)
+	require.Len(t, gpus, 2)
+
+	first := gpus["0000:01:00.0"]
+	require.NotNil(t, first)
+	assert.Equal(t, 64.0, first.Temperature)
+	assert.Equal(t, 97.0, first.Usage.Utilization)
+	assert.Equal(t, 41.0, first.Usage.MemoryUtilization)
+	assert.Equal(t, uint64(20480*1024*1024), first.Usage.VRAMUsed)
+	assert.InDelta(t, 312.45, first.Usage.Power, 0.001)
+	assert.InDelta(t, 450.0, first.Usage.PowerCap, 0.001)
+	assert.Equal(t, 2730, first.Usage.CoreClock)
+	assert.Equal(t, 10501, first.Usage.MemoryClockMax)
+
+	second := gpus["0000:41:00.0"]
+	require.NotNil(t, second)
+	assert.Equal(t, 38.0, second.Temperature)
+	assert.Zero(t, second.Usage.Power)
+	assert.Zero(t, second.Usage.PowerCap)
+
+	// Two cards of the same model keep their own readings
+	entry := gpuEntry{BDF: "0000:41:00.0", Driver: "nvidia"}
+	temp, hwmon := gpuTemperature(entry, "10de:2684", gpus)
+	assert.Equal(t, 38.0, temp)
+	assert.Equal(t, "nvidia", hwmon)
+}
+
+func TestQueryNvidiaSMIMissing(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := queryNvidiaSMI()
+	assert.Error(t, err)
+
+	entry := gpuEntry{BDF: "0000:01:00.0", Driver: "nvidia"}
+	nvidia := (&GopsUtil{sysRoot: t.TempDir()}).queryNvidia([]gpuEntry{entry})
+	assert.Empty(t, nvidia)
+
+	temp, hwmon := gpuTemperature(entry, "10de:2684", nvidia)
+	assert.Zero(t, temp)
+	assert.Equal(t, "unknown", hwmon)
+}
+
+func TestQueryNvidiaLeavesSuspendedCardsAsleep(t *testing.T) {
+	ran := filepath.Join(t.TempDir(), "ran")
+	fakeCommand(t, "nvidia-smi", "touch "+ran+"\n"+
+		"echo '00000000:01:00.0, 64, 97, 41, 20480, 24564, 312.45, 450.00, 2730, 3105, 10501, 10501'\n"+
+		"echo '00000000:41:00.0, 38, 0, 0, 1, 24564, [N/A], [Not Supported], 210, 3105, 405, 10501'\n")
+
+	root := t.TempDir()
+	gopsUtil := &GopsUtil{sysRoot: root}
+	first := gpuEntry{BDF: "0000:01:00.0", Driver: "nvidia"}
+	second := gpuEntry{BDF: "0000:41:00.0", Driver: "nvidia"}
+	status := func(bdf, value string) {
+		writeFixture(t, filepath.Join(root, "bus", "pci", "devices", bdf, "power", "runtime_status"), value+"\n")
+	}
+
+	status(first.BDF, "suspended")
+	status(second.BDF, "suspended")
+	assert.Empty(t, gopsUtil.queryNvidia([]gpuEntry{first, second}))
+	assert.NoFileExists(t, ran, "nvidia-smi ran while every card was suspended")
+
+	// Other drivers are never asked about
+	assert.Empty(t, gopsUtil.queryNvidia([]gpuEntry{{BDF: "0000:03:00.0", Driver: "amdgpu"}}))
+	assert.NoFileExists(t, ran)
+
+	status(second.BDF, "active")
+	gpus := gopsUtil.queryNvidia([]gpuEntry{first, second})
+	assert.FileExists(t, ran)
+	assert.Len(t, gpus, 1)
+	assert.Contains(t, gpus, second.BDF)
+}
+
+func TestQueryNvidiaSMITimesOut(t *testing.T) {
+	fakeCommand(t, "nvidia-smi", "exec sleep 10\n")
+	defer func(timeout time.Duration) { nvidiaSMITimeout = timeout }(nvidiaSMITimeout)
+	nvidiaSMITimeout = 100 * time.Millisecond
+
+	start := time.Now()
+	_, err := queryNvidiaSMI()
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestNormalizeNvidiaBusID(t *testing.T) {
+	bdf, ok := normalizeNvidiaBusID("00000000:0A:00.0")
+	assert.True(t, ok)
+	assert.Equal(t, "0000:0a:00.0", bdf)
+
+	bdf, ok = normalizeNvidiaBusID("00000001:01:00.0")
+	assert.True(t, ok)
+	assert.Equal(t, "0001:01:00.0", bdf)
+
+	_, ok = normalizeNvidiaBusID("No devices were found")
+	assert.False(t, ok)
+}
@@ -0,0 +1,238 @@
+package gops
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// pciIDsEnv points at a pci.ids outside the usual places, as on NixOS
+const pciIDsEnv = "DGOP_PCI_IDS"
+
+// pciIDsPaths are where distributions install the pci.ids database
+var pciIDsPaths = []string{
+	"/usr/share/hwdata/pci.ids",
+	"/usr/share/misc/pci.ids",
+	"/usr/share/pci.ids",
+	"/usr/local/share/pci.ids",
+}
+
+// pciVendors names the vendors likely to show up as a display controller
+// when pci.ids isn't installed
+var pciVendors = map[uint16]string{
+	0x1002: "Advanced Micro Devices, Inc. [AMD/ATI]",
+	0x102b: "Matrox Electronics Systems Ltd.",
+	0x10de: "NVIDIA Corporation",
+	0x1234: "QEMU",
+	0x1414: "Microsoft Corporation",
+	0x15ad: "VMware",
+	0x1a03: "ASPEED Technology, Inc.",
+	0x1af4: "Red Hat, Inc.",
+	0x1b36: "Red Hat, Inc.",
+	0x1ed5: "Moore Threads Technology Co.,Ltd",
+	0x80ee: "InnoTek Systemberatung GmbH",
+	0x8086: "Intel Corporation",
+}
+
+// pciDisplayClasses are the subclasses of PCI class 0x03 as lspci names them
+var pciDisplayClasses = map[uint32]string{
+	0x0300: "VGA compatible controller",
+	0x0301: "XGA compatible controller",
+	0x0302: "3D controller",
+	0x0380: "Display controller",
+}
+
+type pciDevice struct {
+	BDF       string
+	Class     uint32 // 0x030000
+	Vendor    uint16
+	Device    uint16
+	SubVendor uint16
+	SubDevice uint16
+	Revision  uint8
+	Driver    string
+}
+
+type pciNames struct {
+	Vendor    string
+	Device    string
+	Subsystem string
+}
+
+// listDisplayDevices returns every PCI device of class 0x03xxxx
+func (self *GopsUtil) listDisplayDevices() ([]pciDevice, error) {
+	devicesDir := self.sysPath("bus", "pci", "devices")
+	entries, err := os.ReadDir(devicesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []pciDevice
+	for _, entry := range entries {
+		device, ok := readPCIDevice(filepath.Join(devicesDir, entry.Name()))
+		if !ok || device.Class>>16 != 0x03 {
+			continue
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// pciSuspended reports whether runtime power management has put the device
+// to sleep
+func (self *GopsUtil) pciSuspended(bdf string) bool {
+	return readSysfsString(self.sysPath("bus", "pci", "devices", bdf, "power", "runtime_status")) == "suspended"
+}
+
+func readPCIDevice(dir string) (pciDevice, bool) {
+	hex := func(name string) (uint64, bool) {
+		v, err := strconv.ParseUint(readSysfsString(filepath.Join(dir, name)), 0, 32)
+		return v, err == nil
+	}
+
+	class, ok := hex("class")
+	if !ok {
+		return pciDevice{}, false
+	}
+	vendor, _ := hex("vendor")
+	device, _ := hex("device")
+	subVendor, _ := hex("subsystem_vendor")
+	subDevice, _ := hex("subsystem_device")
+	revision, _ := hex("revision")
+
+	pci := pciDevice{
+		BDF:       filepath.Base(dir),
+		Class:     uint32(class),
+		Vendor:    uint16(vendor),
+		Device:    uint16(device),
+		SubVendor: uint16(subVendor),
+		SubDevice: uint16(subDevice),
+		Revision:  uint8(revision),
+	}
+	if link, err := os.Readlink(filepath.Join(dir, "driver")); err == nil {
+		pci.Driver = filepath.Base(link)
+	}
+	return pci, true
+}
+
+// lspciLine formats the device the way `lspci -nnD` prints it, which is
+// what GPU.RawLine has always held
+func (dev pciDevice) lspciLine(names pciNames) string {
+	class := dev.Class >> 8
+	className, ok := pciDisplayClasses[class]
+	if !ok {
+		className = "Display controller"
+	}
+
+	vendor := names.Vendor
+	if vendor == "" {
+		vendor = fmt.Sprintf("Vendor %04x", dev.Vendor)
+	}
+	device := names.Device
+	if device == "" {
+		device = fmt.Sprintf("Device %04x", dev.Device)
+	}
+
+	line := fmt.Sprintf("%s %s [%04x]: %s %s [%04x:%04x]",
+		dev.BDF, className, class, vendor, device, dev.Vendor, dev.Device)
+	if dev.Revision != 0 {
+		line += fmt.Sprintf(" (rev %02x)", dev.Revision)
+	}
+	return line
+}
+
+var (
+	pciNamesMu    sync.Mutex
+	pciNamesCache = make(map[string]pciNames)
+)
+
+// lookupPCINames resolves names from pci.ids, falling back to a vendor name
+// only. Results are cached since the database is over a megabyte.
+func lookupPCINames(dev pciDevice) pciNames {
+	key := fmt.Sprintf("%04x:%04x:%04x:%04x", dev.Vendor, dev.Device, dev.SubVendor, dev.SubDevice)
+
+	pciNamesMu.Lock()
+	defer pciNamesMu.Unlock()
+
+	paths := pciIDsPaths
+	if path := os.Getenv(pciIDsEnv); path != "" {
+		paths = append([]string{path}, paths...)
+	}
+
+	for _, path := range paths {
+		cacheKey := path + "|" + key
+		if names, ok := pciNamesCache[cacheKey]; ok {
+			return names
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		names := parsePCIIDs(bufio.NewScanner(file), dev)
+		file.Close()
+
+		if names.Vendor == "" {
+			names.Vendor = pciVendors[dev.Vendor]
+		}
+		pciNamesCache[cacheKey] = names
+		return names
+	}
+
+	return pciNames{Vendor: pciVendors[dev.Vendor]}
+}
+
+// parsePCIIDs scans pci.ids for the vendor, device and subsystem of dev:
+//
+//	vendor  vendor_name
+//		device  device_name
+//			subvendor subdevice  subsystem_name
+func parsePCIIDs(scanner *bufio.Scanner, dev pciDevice) pciNames {
+	names := pciNames{}
+	inVendor, inDevice := false, false
+	vendorID := fmt.Sprintf("%04x", dev.Vendor)
+	deviceID := fmt.Sprintf("%04x", dev.Device)
+	subsystemID := fmt.Sprintf("%04x %04x", dev.SubVendor, dev.SubDevice)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "\t\t"):
+			if inDevice && strings.HasPrefix(line[2:], subsystemID+" ") {
+				names.Subsystem = strings.TrimSpace(line[2+len(subsystemID):])
+				return names
+			}
+		case line[0] == '\t':
+			if !inVendor {
+				continue
+			}
+			if inDevice {
+				// Past our device without a subsystem match
+				return names
+			}
+			if strings.HasPrefix(line[1:], deviceID+" ") {
+				names.Device = strings.TrimSpace(line[1+len(deviceID):])
+				inDevice = true
+			}
+		default:
+			if inVendor || strings.HasPrefix(line, "C ") {
+				// Vendors are sorted and the device classes come last
+				return names
+			}
+			if strings.HasPrefix(line, vendorID+" ") {
+				names.Vendor = strings.TrimSpace(line[len(vendorID):])
+				inVendor = true
+			}
+		}
+	}
+
+	return names
+}
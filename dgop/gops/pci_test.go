@@ -0,0 +1,106 @@
+package gops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPCIIDs = `# pci.ids excerpt
+1002  Advanced Micro Devices, Inc. [AMD/ATI]
+	7448  Navi 31 [Radeon Pro W7900]
+	744c  Navi 31 [Radeon RX 7900 XT/7900 XTX/7900 GRE/7900M]
+		1002 0e3b  Radeon RX 7900 GRE
+		1da2 e471  NITRO+ RX 7900 XTX Vapor-X
+	7460  Navi 32 GL
+10de  NVIDIA Corporation
+	2684  AD102 [GeForce RTX 4090]
+8086  Intel Corporation
+	a7a0  Raptor Lake-P [Iris Xe Graphics]
+C 03  Display controller
+	00  VGA compatible controller
+`
+
+func pciFixture(t *testing.T) string {
+	root := t.TempDir()
+	writeSysfsAttrs(t, filepath.Join(root, "bus", "pci", "devices", "0000:00:02.0"), map[string]string{
+		"class": "0x030000", "vendor": "0x8086", "device": "0xa7a0",
+		"subsystem_vendor": "0x17aa", "subsystem_device": "0x22e4", "revision": "0x04",
+	})
+	writeSysfsAttrs(t, filepath.Join(root, "bus", "pci", "devices", "0000:03:00.0"), map[string]string{
+		"class": "0x030000", "vendor": "0x1002", "device": "0x744c",
+		"subsystem_vendor": "0x1da2", "subsystem_device": "0xe471", "revision": "0xc8",
+	})
+	writeSysfsAttrs(t, filepath.Join(root, "bus", "pci", "devices", "0000:41:00.0"), map[string]string{
+		"class": "0x030200", "vendor": "0x10de", "device": "0x2999",
+		"subsystem_vendor": "0x10de", "subsystem_device": "0x16f1", "revision": "0xa1",
+	})
+	// The GPU's HDMI audio function is not a display controller
+	writeSysfsAttrs(t, filepath.Join(root, "bus", "pci", "devices", "0000:03:00.1"), map[string]string{
+		"class": "0x040300", "vendor": "0x1002", "device": "0xab30",
+	})
+
+	driversDir := filepath.Join(root, "bus", "pci", "drivers")
+	for bdf, driver := range map[string]string{"0000:00:02.0": "i915", "0000:03:00.0": "amdgpu", "0000:41:00.0": "nvidia"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(driversDir, driver), 0o755))
+		require.NoError(t, os.Symlink(filepath.Join(driversDir, driver), filepath.Join(root, "bus", "pci", "devices", bdf, "driver")))
+	}
+	return root
+}
+
+func usePCIIDs(t *testing.T, content string) {
+	paths := pciIDsPaths
+	path := filepath.Join(t.TempDir(), "pci.ids")
+	if content != "" {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	pciIDsPaths = []string{path}
+	t.Cleanup(func() { pciIDsPaths = paths })
+}
+
+func TestDetectGPUsFromSysfs(t *testing.T) {
+	usePCIIDs(t, testPCIIDs)
+	gopsUtil := &GopsUtil{sysRoot: pciFixture(t)}
+
+	gpus, err := gopsUtil.detectGPUs()
+	require.NoError(t, err)
+	require.Len(t, gpus, 3)
+
+	// nvidia sorts first, then the discrete amdgpu, then Intel
+	nvidia := gpus[0]
+	assert.Equal(t, "0000:41:00.0", nvidia.BDF)
+	assert.Equal(t, "NVIDIA", nvidia.Vendor)
+	assert.Equal(t, "10de:2999", nvidia.PciId)
+	assert.Equal(t, "0000:41:00.0 3D controller [0302]: NVIDIA Corporation Device 2999 [10de:2999] (rev a1)", nvidia.RawLine)
+
+	amd := gpus[1]
+	assert.Equal(t, "amdgpu", amd.Driver)
+	assert.Equal(t, "0000:03:00.0 VGA compatible controller [0300]: Advanced Micro Devices, Inc. [AMD/ATI] Navi 31 [Radeon RX 7900 XT/7900 XTX/7900 GRE/7900M] [1002:744c] (rev c8)", amd.RawLine)
+	assert.Equal(t, "Radeon RX 7900 XT/7900 XTX/7900 GRE/7900M", amd.DisplayName)
+	assert.Equal(t, "1002:744c", amd.PciId)
+	assert.Equal(t, "1da2:e471", amd.SubsystemId)
+	assert.Equal(t, "NITRO+ RX 7900 XTX Vapor-X", amd.Subsystem)
+
+	intel := gpus[2]
+	assert.Equal(t, "i915", intel.Driver)
+	assert.Equal(t, "Iris Xe Graphics", intel.DisplayName)
+	assert.Equal(t, "Intel Iris Xe Graphics", intel.FullName)
+	assert.Empty(t, intel.Subsystem)
+}
+
+func TestDetectGPUsWithoutPCIIDs(t *testing.T) {
+	usePCIIDs(t, "")
+	gopsUtil := &GopsUtil{sysRoot: pciFixture(t)}
+
+	gpus, err := gopsUtil.detectGPUs()
+	require.NoError(t, err)
+	require.Len(t, gpus, 3)
+
+	amd := gpus[1]
+	assert.Equal(t, "0000:03:00.0 VGA compatible controller [0300]: Advanced Micro Devices, Inc. [AMD/ATI] Device 744c [1002:744c] (rev c8)", amd.RawLine)
+	assert.Equal(t, "AMD", amd.Vendor)
+	assert.Equal(t, "1002:744c", amd.PciId)
+}
@@ -0,0 +1,240 @@
+package gops
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AvengeMedia/dgop/models"
+)
+
+// PowerCursor keeps the last energy reading of each battery. Firmware often
+// only refreshes energy_now every few tens of seconds, so a reading is kept
+// until the battery reports a different value.
+type PowerCursor struct {
+	Batteries map[string]BatterySample `json:"batteries"`
+}
+
+type BatterySample struct {
+	Timestamp time.Time `json:"timestamp"`
+	Energy    float64   `json:"energy"`
+	Status    string    `json:"status"`
+}
+
+// GetPowerInfo reads batteries and adapters from /sys/class/power_supply.
+// Time estimates use the drain or charge rate measured since the cursor,
+// falling back to the power the battery reports.
+func (self *GopsUtil) GetPowerInfo(cursorStr string) (*models.PowerInfo, error) {
+	info := &models.PowerInfo{
+		Batteries: make([]*models.BatteryInfo, 0),
+		Adapters:  make([]*models.PowerAdapterInfo, 0),
+	}
+
+	supplyDir := self.sysPath("class", "power_supply")
+	entries, err := os.ReadDir(supplyDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var cursor PowerCursor
+	if cursorStr != "" {
+		// A bad cursor only costs the estimate, fall back to power_now
+		cursor, _ = parsePowerCursor(cursorStr)
+	}
+
+	now := time.Now()
+	newCursor := PowerCursor{Batteries: make(map[string]BatterySample)}
+
+	for _, entry := range entries {
+		dir := filepath.Join(supplyDir, entry.Name())
+
+		switch readSysfsString(filepath.Join(dir, "type")) {
+		case "Battery":
+			battery := readBattery(dir, entry.Name())
+
+			prev, hasPrev := cursor.Batteries[battery.Name]
+			var prevSample *BatterySample
+			if hasPrev {
+				prevSample = &prev
+			}
+			estimateBatteryTimes(battery, prevSample, now)
+
+			sample := BatterySample{Timestamp: now, Energy: battery.EnergyNow, Status: battery.Status}
+			if hasPrev && prev.Energy == sample.Energy && prev.Status == sample.Status {
+				sample = prev
+			}
+			newCursor.Batteries[battery.Name] = sample
+
+			info.Batteries = append(info.Batteries, battery)
+		default:
+			online, ok := readSysfsInt(filepath.Join(dir, "online"))
+			if !ok {
+				continue
+			}
+			info.Adapters = append(info.Adapters, &models.PowerAdapterInfo{
+				Name:   entry.Name(),
+				Type:   readSysfsString(filepath.Join(dir, "type")),
+				Online: online == 1,
+			})
+		}
+	}
+
+	info.OnBattery = onBattery(info)
+
+	if len(info.Batteries) > 0 {
+		cursorStr, err := encodePowerCursor(newCursor)
+		if err != nil {
+			return nil, err
+		}
+		info.Cursor = cursorStr
+	}
+
+	return info, nil
+}
+
+func readBattery(dir, name string) *models.BatteryInfo {
+	attr := func(name string) string { return filepath.Join(dir, name) }
+
+	battery := &models.BatteryInfo{
+		Name:         name,
+		Scope:        readSysfsString(attr("scope")),
+		Manufacturer: readSysfsString(attr("manufacturer")),
+		Model:        readSysfsString(attr("model_name")),
+		Technology:   readSysfsString(attr("technology")),
+		Status:       readSysfsString(attr("status")),
+		Health:       readSysfsString(attr("health")),
+	}
+
+	voltage, _ := readSysfsMicro(attr("voltage_now"))
+	battery.Voltage = voltage
+
+	if energy, ok := readSysfsMicro(attr("energy_now")); ok {
+		battery.EnergyNow = energy
+		battery.EnergyFull, _ = readSysfsMicro(attr("energy_full"))
+		battery.EnergyFullDesign, _ = readSysfsMicro(attr("energy_full_design"))
+	} else if charge, ok := readSysfsMicro(attr("charge_now")); ok {
+		// Ah to Wh, preferring the nominal voltage over the fluctuating one
+		nominal, ok := readSysfsMicro(attr("voltage_min_design"))
+		if !ok {
+			nominal = voltage
+		}
+		chargeFull, _ := readSysfsMicro(attr("charge_full"))
+		chargeFullDesign, _ := readSysfsMicro(attr("charge_full_design"))
+		battery.EnergyNow = charge * nominal
+		battery.EnergyFull = chargeFull * nominal
+		battery.EnergyFullDesign = chargeFullDesign * nominal
+	}
+
+	// Some drivers report a negative value while discharging
+	if power, ok := readSysfsMicro(attr("power_now")); ok {
+		battery.PowerNow = math.Abs(power)
+	} else if current, ok := readSysfsMicro(attr("current_now")); ok {
+		battery.PowerNow = math.Abs(current) * voltage
+	}
+
+	if capacity, ok := readSysfsInt(attr("capacity")); ok {
+		battery.Capacity = int(capacity)
+	} else if battery.EnergyFull > 0 {
+		battery.Capacity = int(math.Round(battery.EnergyNow / battery.EnergyFull * 100))
+	}
+
+	if cycles, ok := readSysfsInt(attr("cycle_count")); ok {
+		battery.CycleCount = int(cycles)
+	}
+
+	if battery.EnergyFullDesign > 0 {
+		battery.HealthPercent = battery.EnergyFull / battery.EnergyFullDesign * 100
+	}
+
+	return battery
+}
+
+// estimateBatteryTimes fills TimeToEmpty or TimeToFull from the energy change
+// since prev, or from PowerNow when prev is missing or doesn't show a change
+// in the expected direction
+func estimateBatteryTimes(battery *models.BatteryInfo, prev *BatterySample, now time.Time) {
+	rate := battery.PowerNow
+	if prev != nil && prev.Status == battery.Status {
+		hours := now.Sub(prev.Timestamp).Hours()
+		delta := prev.Energy - battery.EnergyNow
+		if battery.Status == "Charging" {
+			delta = -delta
+		}
+		if hours > 0 && delta > 0 {
+			rate = delta / hours
+		}
+	}
+
+	if rate <= 0 {
+		return
+	}
+
+	switch battery.Status {
+	case "Discharging":
+		battery.TimeToEmpty = int64(battery.EnergyNow / rate * 3600)
+	case "Charging":
+		if battery.EnergyFull > battery.EnergyNow {
+			battery.TimeToFull = int64((battery.EnergyFull - battery.EnergyNow) / rate * 3600)
+		}
+	}
+}
+
+// onBattery is true when a system battery is the only source of power.
+// Peripheral batteries such as a wireless mouse are ignored.
+func onBattery(info *models.PowerInfo) bool {
+	discharging := false
+	hasSystemBattery := false
+	for _, battery := range info.Batteries {
+		if battery.Scope == "Device" {
+			continue
+		}
+		hasSystemBattery = true
+		discharging = discharging || battery.Status == "Discharging"
+	}
+	if !hasSystemBattery {
+		return false
+	}
+
+	// Without an adapter to ask, trust the battery status
+	if len(info.Adapters) == 0 {
+		return discharging
+	}
+	for _, adapter := range info.Adapters {
+		if adapter.Online {
+			return false
+		}
+	}
+	return true
+}
+
+// readSysfsMicro reads a µWh, µAh, µW, µA or µV attribute in whole units
+func readSysfsMicro(path string) (float64, bool) {
+	v, ok := readSysfsInt(path)
+	if !ok {
+		return 0, false
+	}
+	return float64(v) / 1e6, true
+}
+
+func encodePowerCursor(cursor PowerCursor) (string, error) {
+	jsonData, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(jsonData), nil
+}
+
+func parsePowerCursor(cursorStr string) (PowerCursor, error) {
+	var cursor PowerCursor
+
+	jsonData, err := base64.StdEncoding.DecodeString(cursorStr)
+	if err != nil {
+		return cursor, err
+	}
+
+	err = json.Unmarshal(jsonData, &cursor)
+	return cursor, err
+}
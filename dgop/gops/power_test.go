@@ -0,0 +1,124 @@
+package gops
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func powerFixture(t *testing.T) string {
+	root := t.TempDir()
+	writeSysfsAttrs(t, filepath.Join(root, "class", "power_supply", "AC"), map[string]string{
+		"type":   "Mains",
+		"online": "0",
+	})
+	writeSysfsAttrs(t, filepath.Join(root, "class", "power_supply", "BAT0"), map[string]string{
+		"type":               "Battery",
+		"status":             "Discharging",
+		"capacity":           "50",
+		"energy_now":         "25000000",
+		"energy_full":        "50000000",
+		"energy_full_design": "62500000",
+		"power_now":          "10000000",
+		"voltage_now":        "12000000",
+		"cycle_count":        "321",
+		"manufacturer":       "SMP",
+		"model_name":         "5B10W13930",
+	})
+	// Charge based battery without power_now or capacity
+	writeSysfsAttrs(t, filepath.Join(root, "class", "power_supply", "BAT1"), map[string]string{
+		"type":               "Battery",
+		"status":             "Charging",
+		"charge_now":         "2000000",
+		"charge_full":        "4000000",
+		"charge_full_design": "4000000",
+		"current_now":        "-1000000",
+		"voltage_now":        "12500000",
+		"voltage_min_design": "12000000",
+	})
+	writeSysfsAttrs(t, filepath.Join(root, "class", "power_supply", "hidpp_battery_0"), map[string]string{
+		"type":     "Battery",
+		"scope":    "Device",
+		"status":   "Discharging",
+		"capacity": "80",
+	})
+	return root
+}
+
+func TestGetPowerInfo(t *testing.T) {
+	gopsUtil := &GopsUtil{sysRoot: powerFixture(t)}
+
+	info, err := gopsUtil.GetPowerInfo("")
+	require.NoError(t, err)
+
+	require.Len(t, info.Adapters, 1)
+	assert.Equal(t, "Mains", info.Adapters[0].Type)
+	assert.False(t, info.Adapters[0].Online)
+	assert.True(t, info.OnBattery)
+	assert.NotEmpty(t, info.Cursor)
+
+	require.Len(t, info.Batteries, 3)
+	bat0 := info.Batteries[0]
+	assert.Equal(t, "BAT0", bat0.Name)
+	assert.Equal(t, 50, bat0.Capacity)
+	assert.InDelta(t, 25.0, bat0.EnergyNow, 0.001)
+	assert.InDelta(t, 80.0, bat0.HealthPercent, 0.001)
+	assert.Equal(t, 321, bat0.CycleCount)
+	// 25 Wh at 10 W
+	assert.Equal(t, int64(9000), bat0.TimeToEmpty)
+
+	bat1 := info.Batteries[1]
+	assert.InDelta(t, 24.0, bat1.EnergyNow, 0.001)
+	assert.InDelta(t, 48.0, bat1.EnergyFull, 0.001)
+	assert.Equal(t, 50, bat1.Capacity)
+	assert.InDelta(t, 12.5, bat1.PowerNow, 0.001)
+	assert.Equal(t, int64(6912), bat1.TimeToFull)
+
+	assert.Equal(t, "Device", info.Batteries[2].Scope)
+}
+
+func TestPowerCursorKeepsUnchangedReading(t *testing.T) {
+	gopsUtil := &GopsUtil{sysRoot: powerFixture(t)}
+
+	first, err := gopsUtil.GetPowerInfo("")
+	require.NoError(t, err)
+	second, err := gopsUtil.GetPowerInfo(first.Cursor)
+	require.NoError(t, err)
+
+	before, err := parsePowerCursor(first.Cursor)
+	require.NoError(t, err)
+	after, err := parsePowerCursor(second.Cursor)
+	require.NoError(t, err)
+	assert.True(t, before.Batteries["BAT0"].Timestamp.Equal(after.Batteries["BAT0"].Timestamp))
+}
+
+func TestEstimateBatteryTimes(t *testing.T) {
+	now := time.Now()
+
+	// Lost 2 Wh in 12 minutes, 10 W, so 20 Wh lasts 2 hours
+	battery := &models.BatteryInfo{Status: "Discharging", EnergyNow: 20, PowerNow: 40}
+	estimateBatteryTimes(battery, &BatterySample{Timestamp: now.Add(-12 * time.Minute), Energy: 22, Status: "Discharging"}, now)
+	assert.Equal(t, int64(7200), battery.TimeToEmpty)
+
+	// The cursor was taken while charging, use power_now instead
+	battery = &models.BatteryInfo{Status: "Discharging", EnergyNow: 20, PowerNow: 40}
+	estimateBatteryTimes(battery, &BatterySample{Timestamp: now.Add(-12 * time.Minute), Energy: 18, Status: "Charging"}, now)
+	assert.Equal(t, int64(1800), battery.TimeToEmpty)
+
+	battery = &models.BatteryInfo{Status: "Full", EnergyNow: 50, EnergyFull: 50, PowerNow: 0}
+	estimateBatteryTimes(battery, nil, now)
+	assert.Zero(t, battery.TimeToEmpty)
+	assert.Zero(t, battery.TimeToFull)
+}
+
+func TestGetPowerInfoWithoutSupplies(t *testing.T) {
+	info, err := (&GopsUtil{sysRoot: t.TempDir()}).GetPowerInfo("")
+	require.NoError(t, err)
+	assert.Empty(t, info.Batteries)
+	assert.False(t, info.OnBattery)
+	assert.Empty(t, info.Cursor)
+}
@@ -0,0 +1,182 @@
+package gops
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/AvengeMedia/dgop/errdefs"
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// Signals accepted by name, anything else can still be given by number
+var signalNames = map[string]syscall.Signal{
+	"HUP":  syscall.SIGHUP,
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"KILL": syscall.SIGKILL,
+	"USR1": syscall.SIGUSR1,
+	"USR2": syscall.SIGUSR2,
+	"TERM": syscall.SIGTERM,
+	"CONT": syscall.SIGCONT,
+	"STOP": syscall.SIGSTOP,
+	"TSTP": syscall.SIGTSTP,
+}
+
+// ParseSignal accepts TERM, SIGTERM, term or 15
+func ParseSignal(name string) (syscall.Signal, error) {
+	if n, err := strconv.Atoi(name); err == nil {
+		if n <= 0 || n > 64 {
+			return 0, errdefs.NewCustomError(errdefs.ErrTypeInvalidInput, fmt.Sprintf("invalid signal number %d", n))
+		}
+		return syscall.Signal(n), nil
+	}
+
+	sig, ok := signalNames[strings.TrimPrefix(strings.ToUpper(name), "SIG")]
+	if !ok {
+		return 0, errdefs.NewCustomError(errdefs.ErrTypeInvalidInput, fmt.Sprintf("unknown signal %q", name))
+	}
+	return sig, nil
+}
+
+// SignalName returns SIGTERM style names, or the number for unnamed signals
+func SignalName(sig syscall.Signal) string {
+	for name, s := range signalNames {
+		if s == sig {
+			return "SIG" + name
+		}
+	}
+	return strconv.Itoa(int(sig))
+}
+
+// SignalProcess sends sig to pid, see checkProcessAction for what is refused
+func (self *GopsUtil) SignalProcess(pid int32, sig syscall.Signal) error {
+	if err := checkProcessAction(pid); err != nil {
+		return err
+	}
+
+	if err := syscall.Kill(int(pid), sig); err != nil {
+		return processActionError(pid, err)
+	}
+	return nil
+}
+
+// ReniceProcess sets the nice value of pid. Lowering it usually needs root.
+func (self *GopsUtil) ReniceProcess(pid int32, nice int) error {
+	if nice < -20 || nice > 19 {
+		return errdefs.NewCustomError(errdefs.ErrTypeInvalidInput, fmt.Sprintf("nice value %d is outside -20..19", nice))
+	}
+	if err := checkProcessAction(pid); err != nil {
+		return err
+	}
+
+	if err := syscall.Setpriority(syscall.PRIO_PROCESS, int(pid), nice); err != nil {
+		return processActionError(pid, err)
+	}
+	return nil
+}
+
+// GetProcessNice returns the current nice value of pid
+func (self *GopsUtil) GetProcessNice(pid int32) (int, error) {
+	// The raw syscall returns 20 - nice so that it is never negative.
+	// gopsutil's Nice() hands back that raw value.
+	prio, err := syscall.Getpriority(syscall.PRIO_PROCESS, int(pid))
+	if err != nil {
+		return 0, processActionError(pid, err)
+	}
+	return 20 - prio, nil
+}
+
+// MatchProcesses finds processes whose name matches the regular expression
+// pattern, or the full command line when full is set. A plain number matches
+// that PID. The calling process is never matched.
+func (self *GopsUtil) MatchProcesses(pattern string, full bool) ([]*models.ProcessInfo, error) {
+	if pid, err := strconv.ParseInt(pattern, 10, 32); err == nil {
+		proc, err := process.NewProcess(int32(pid))
+		if err != nil {
+			return nil, errdefs.NewCustomError(errdefs.ErrTypeNotFound, fmt.Sprintf("no process with pid %d", pid))
+		}
+		return []*models.ProcessInfo{describeProcess(proc)}, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errdefs.NewCustomError(errdefs.ErrTypeInvalidInput, fmt.Sprintf("invalid pattern: %v", err))
+	}
+
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	ownPID := int32(os.Getpid())
+	matches := make([]*models.ProcessInfo, 0)
+	for _, proc := range procs {
+		if proc.Pid == ownPID {
+			continue
+		}
+		info := describeProcess(proc)
+		target := info.Command
+		if full {
+			target = info.FullCommand
+		}
+		if re.MatchString(target) {
+			matches = append(matches, info)
+		}
+	}
+
+	sortProcesses(matches, SortByPID)
+	return matches, nil
+}
+
+func describeProcess(proc *process.Process) *models.ProcessInfo {
+	info := &models.ProcessInfo{PID: proc.Pid}
+	info.PPID, _ = proc.Ppid()
+	info.Username, _ = proc.Username()
+	info.Command, _ = proc.Name()
+	info.FullCommand, _ = proc.Cmdline()
+	return info
+}
+
+// checkProcessAction refuses init, the dgop process itself and, unless
+// running as root, processes owned by another user
+func checkProcessAction(pid int32) error {
+	if pid <= 1 {
+		return errdefs.NewCustomError(errdefs.ErrTypePermissionDenied, fmt.Sprintf("refusing to act on pid %d", pid))
+	}
+	if int(pid) == os.Getpid() {
+		return errdefs.NewCustomError(errdefs.ErrTypePermissionDenied, "refusing to act on dgop itself")
+	}
+
+	proc, err := process.NewProcess(pid)
+	if err != nil {
+		return errdefs.NewCustomError(errdefs.ErrTypeNotFound, fmt.Sprintf("no process with pid %d", pid))
+	}
+
+	if euid := os.Geteuid(); euid != 0 {
+		uids, err := proc.Uids()
+		if err != nil || len(uids) == 0 {
+			return errdefs.NewCustomError(errdefs.ErrTypePermissionDenied, fmt.Sprintf("unable to determine the owner of pid %d", pid))
+		}
+		if uids[0] != uint32(euid) {
+			return errdefs.NewCustomError(errdefs.ErrTypePermissionDenied, fmt.Sprintf("pid %d belongs to another user", pid))
+		}
+	}
+
+	return nil
+}
+
+func processActionError(pid int32, err error) error {
+	switch {
+	case errors.Is(err, syscall.ESRCH):
+		return errdefs.NewCustomError(errdefs.ErrTypeNotFound, fmt.Sprintf("no process with pid %d", pid))
+	case errors.Is(err, syscall.EPERM), errors.Is(err, syscall.EACCES):
+		return errdefs.NewCustomError(errdefs.ErrTypePermissionDenied, fmt.Sprintf("not permitted to act on pid %d", pid))
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package gops
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/AvengeMedia/dgop/errdefs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSignal(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected syscall.Signal
+		valid    bool
+	}{
+		"Short name":  {"TERM", syscall.SIGTERM, true},
+		"SIG prefix":  {"SIGKILL", syscall.SIGKILL, true},
+		"Lower case":  {"hup", syscall.SIGHUP, true},
+		"Number":      {"10", syscall.Signal(10), true},
+		"Unknown":     {"BOGUS", 0, false},
+		"Zero number": {"0", 0, false},
+	}
+
+	for name, test := range tests {
+		sig, err := ParseSignal(test.input)
+		if test.valid {
+			assert.NoError(t, err, name)
+			assert.Equal(t, test.expected, sig, name)
+		} else {
+			assert.ErrorIs(t, err, errdefs.ErrInvalidInput, name)
+		}
+	}
+}
+
+func TestProcessActionRefusals(t *testing.T) {
+	g := NewGopsUtil()
+
+	assert.ErrorIs(t, g.SignalProcess(1, syscall.SIGTERM), errdefs.ErrPermissionDenied)
+	assert.ErrorIs(t, g.SignalProcess(int32(os.Getpid()), syscall.SIGTERM), errdefs.ErrPermissionDenied)
+	assert.ErrorIs(t, g.ReniceProcess(int32(os.Getpid()), 5), errdefs.ErrPermissionDenied)
+	assert.ErrorIs(t, g.ReniceProcess(int32(os.Getpid()), 40), errdefs.ErrInvalidInput)
+}
@@ -0,0 +1,261 @@
+package gops
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AvengeMedia/dgop/errdefs"
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+var processStates = map[string]string{
+	"R": "running",
+	"S": "sleeping",
+	"D": "disk sleep",
+	"Z": "zombie",
+	"T": "stopped",
+	"t": "tracing stop",
+	"X": "dead",
+	"I": "idle",
+	"P": "parked",
+}
+
+// GetProcessDetail reads everything /proc has on pid. The environment can
+// hold secrets, so it is only read when includeEnv is set.
+func (self *GopsUtil) GetProcessDetail(pid int32, includeEnv bool) (*models.ProcessDetail, error) {
+	proc, err := process.NewProcess(pid)
+	if err != nil {
+		return nil, errdefs.NewCustomError(errdefs.ErrTypeNotFound, fmt.Sprintf("no process with pid %d", pid))
+	}
+
+	procDir := filepath.Join("/proc", strconv.Itoa(int(pid)))
+
+	statData, err := os.ReadFile(filepath.Join(procDir, "stat"))
+	if err != nil {
+		return nil, errdefs.NewCustomError(errdefs.ErrTypeNotFound, fmt.Sprintf("no process with pid %d", pid))
+	}
+
+	detail, err := parseProcStat(string(statData))
+	if err != nil {
+		return nil, err
+	}
+
+	detail.PID = pid
+	detail.Username, _ = proc.Username()
+	detail.FullCommand, _ = proc.Cmdline()
+	detail.StartTime, _ = proc.CreateTime()
+	detail.Cwd, _ = proc.Cwd()
+	detail.Exe, _ = proc.Exe()
+
+	if includeEnv {
+		detail.Environ, _ = proc.Environ()
+	}
+
+	detail.FDs = readProcessFDs(filepath.Join(procDir, "fd"))
+	detail.FDCount = len(detail.FDs)
+
+	if data, err := os.ReadFile(filepath.Join(procDir, "limits")); err == nil {
+		detail.Rlimits = parseProcLimits(string(data))
+	}
+
+	if data, err := os.ReadFile(filepath.Join(procDir, "cgroup")); err == nil {
+		detail.Cgroup, detail.CgroupControllers = parseProcCgroup(string(data))
+	}
+
+	detail.Namespaces = readProcessNamespaces(filepath.Join(procDir, "ns"))
+
+	if data, err := os.ReadFile(filepath.Join(procDir, "io")); err == nil {
+		detail.IO = parseProcIO(string(data))
+	}
+
+	return detail, nil
+}
+
+// parseProcStat fills the fields of /proc/pid/stat that ProcessDetail needs.
+// The command is in parentheses and may itself contain spaces or parentheses.
+func parseProcStat(data string) (*models.ProcessDetail, error) {
+	open := strings.IndexByte(data, '(')
+	end := strings.LastIndexByte(data, ')')
+	if open < 0 || end < open {
+		return nil, fmt.Errorf("malformed stat line")
+	}
+
+	// Fields after the command, starting from field 3 (state)
+	fields := strings.Fields(data[end+1:])
+	if len(fields) < 18 {
+		return nil, fmt.Errorf("malformed stat line")
+	}
+
+	detail := &models.ProcessDetail{
+		Command:          data[open+1 : end],
+		State:            fields[0],
+		StateDescription: processStates[fields[0]],
+	}
+
+	ppid, _ := strconv.Atoi(fields[1])
+	detail.PPID = int32(ppid)
+	detail.Priority, _ = strconv.Atoi(fields[15])
+	detail.Nice, _ = strconv.Atoi(fields[16])
+	detail.Threads, _ = strconv.Atoi(fields[17])
+
+	return detail, nil
+}
+
+func readProcessFDs(fdDir string) []models.ProcessFD {
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return nil
+	}
+
+	fds := make([]models.ProcessFD, 0, len(entries))
+	for _, entry := range entries {
+		fd, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		// The fd may be closed between listing and reading it
+		target, err := os.Readlink(filepath.Join(fdDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		fds = append(fds, models.ProcessFD{FD: fd, Target: target})
+	}
+
+	sort.Slice(fds, func(i, j int) bool {
+		return fds[i].FD < fds[j].FD
+	})
+	return fds
+}
+
+// Limit names can contain single spaces, columns are separated by two or more
+var limitsColumns = regexp.MustCompile(`\s{2,}`)
+
+func parseProcLimits(data string) []models.ProcessRlimit {
+	limits := make([]models.ProcessRlimit, 0)
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "Limit") {
+			continue
+		}
+
+		cols := limitsColumns.Split(line, -1)
+		if len(cols) < 3 {
+			continue
+		}
+
+		limit := models.ProcessRlimit{
+			Resource: cols[0],
+			Soft:     parseLimitValue(cols[1]),
+			Hard:     parseLimitValue(cols[2]),
+		}
+		if len(cols) > 3 {
+			limit.Unit = cols[3]
+		}
+		limits = append(limits, limit)
+	}
+
+	return limits
+}
+
+func parseLimitValue(s string) int64 {
+	if s == "unlimited" {
+		return -1
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return v
+}
+
+// parseProcCgroup returns the cgroup v2 path and, on v1 or hybrid systems,
+// the path of each named controller hierarchy
+func parseProcCgroup(data string) (string, map[string]string) {
+	var unified string
+	controllers := make(map[string]string)
+
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			unified = parts[2]
+			continue
+		}
+		for _, controller := range strings.Split(parts[1], ",") {
+			if controller != "" {
+				controllers[controller] = parts[2]
+			}
+		}
+	}
+
+	if len(controllers) == 0 {
+		controllers = nil
+	}
+	return unified, controllers
+}
+
+func readProcessNamespaces(nsDir string) map[string]uint64 {
+	entries, err := os.ReadDir(nsDir)
+	if err != nil {
+		return nil
+	}
+
+	namespaces := make(map[string]uint64)
+	for _, entry := range entries {
+		// Links look like net:[4026531840]
+		target, err := os.Readlink(filepath.Join(nsDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		open := strings.IndexByte(target, '[')
+		if open < 0 || !strings.HasSuffix(target, "]") {
+			continue
+		}
+		inode, err := strconv.ParseUint(target[open+1:len(target)-1], 10, 64)
+		if err != nil {
+			continue
+		}
+		namespaces[entry.Name()] = inode
+	}
+
+	if len(namespaces) == 0 {
+		return nil
+	}
+	return namespaces
+}
+
+func parseProcIO(data string) *models.ProcessIOCounters {
+	counters := &models.ProcessIOCounters{}
+	fields := map[string]*uint64{
+		"rchar":                 &counters.ReadChars,
+		"wchar":                 &counters.WriteChars,
+		"syscr":                 &counters.ReadSyscalls,
+		"syscw":                 &counters.WriteSyscalls,
+		"read_bytes":            &counters.ReadBytes,
+		"write_bytes":           &counters.WriteBytes,
+		"cancelled_write_bytes": &counters.CancelledWriteBytes,
+	}
+
+	for _, line := range strings.Split(data, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if field, ok := fields[strings.TrimSpace(key)]; ok {
+			*field, _ = strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		}
+	}
+
+	return counters
+}
@@ -0,0 +1,74 @@
+package gops
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProcStat(t *testing.T) {
+	detail, err := parseProcStat("4242 (Web Content (x)) S 4200 4242 4200 0 -1 4194560 1 0 0 0 5 3 0 0 25 5 31 0 214059 2703360 315 18446744073709551615")
+	assert.NoError(t, err)
+	assert.Equal(t, "Web Content (x)", detail.Command)
+	assert.Equal(t, "S", detail.State)
+	assert.Equal(t, "sleeping", detail.StateDescription)
+	assert.Equal(t, int32(4200), detail.PPID)
+	assert.Equal(t, 25, detail.Priority)
+	assert.Equal(t, 5, detail.Nice)
+	assert.Equal(t, 31, detail.Threads)
+
+	_, err = parseProcStat("garbage")
+	assert.Error(t, err)
+}
+
+func TestParseProcLimits(t *testing.T) {
+	limits := parseProcLimits(`Limit                     Soft Limit           Hard Limit           Units     
+Max cpu time              unlimited            unlimited            seconds   
+Max open files            1024                 524288               files     
+Max pending signals       23379                23379                signals   
+`)
+
+	assert.Len(t, limits, 3)
+	assert.Equal(t, "Max cpu time", limits[0].Resource)
+	assert.Equal(t, int64(-1), limits[0].Soft)
+	assert.Equal(t, int64(1024), limits[1].Soft)
+	assert.Equal(t, int64(524288), limits[1].Hard)
+	assert.Equal(t, "files", limits[1].Unit)
+}
+
+func TestParseProcCgroup(t *testing.T) {
+	unified, controllers := parseProcCgroup("0::/user.slice/user-1000.slice/session-2.scope\n")
+	assert.Equal(t, "/user.slice/user-1000.slice/session-2.scope", unified)
+	assert.Nil(t, controllers)
+
+	unified, controllers = parseProcCgroup("4:memory:/docker/abc\n2:cpu,cpuacct:/\n1:name=systemd:/init.scope\n0::/\n")
+	assert.Equal(t, "/", unified)
+	assert.Equal(t, map[string]string{
+		"memory":       "/docker/abc",
+		"cpu":          "/",
+		"cpuacct":      "/",
+		"name=systemd": "/init.scope",
+	}, controllers)
+}
+
+func TestParseProcIO(t *testing.T) {
+	io := parseProcIO("rchar: 3980\nwchar: 12\nsyscr: 9\nsyscw: 1\nread_bytes: 4096\nwrite_bytes: 8192\ncancelled_write_bytes: 0\n")
+	assert.Equal(t, uint64(3980), io.ReadChars)
+	assert.Equal(t, uint64(12), io.WriteChars)
+	assert.Equal(t, uint64(9), io.ReadSyscalls)
+	assert.Equal(t, uint64(4096), io.ReadBytes)
+	assert.Equal(t, uint64(8192), io.WriteBytes)
+}
+
+func TestGetProcessDetailSelf(t *testing.T) {
+	detail, err := NewGopsUtil().GetProcessDetail(int32(os.Getpid()), false)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(os.Getppid()), detail.PPID)
+	assert.NotZero(t, detail.Threads)
+	assert.NotZero(t, detail.FDCount)
+	assert.Nil(t, detail.Environ)
+
+	_, err = NewGopsUtil().GetProcessDetail(-5, false)
+	assert.Error(t, err)
+}
@@ -46,6 +46,44 @@ func (self *GopsUtil) GetProcesses(sortBy ProcSortBy, limit int, enableCPU bool)
 }
 
 func (self *GopsUtil) GetProcessesWithCursor(sortBy ProcSortBy, limit int, enableCPU bool, cursor string) (*models.ProcessListResponse, error) {
+	return self.GetFilteredProcesses(sortBy, limit, enableCPU, cursor, ProcessFilter{})
+}
+
+// GetFilteredProcesses drops the processes not matching filter before sorting
+// and applying limit, so the limit counts only matching processes
+func (self *GopsUtil) GetFilteredProcesses(sortBy ProcSortBy, limit int, enableCPU bool, cursor string, filter ProcessFilter) (*models.ProcessListResponse, error) {
+	keep, err := filter.matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a cursor, answer from the background sampler when it is running
+	if cursor == "" {
+		if cached, sampledAt, ok := self.sampler.latestProcesses(); ok {
+			// apply copies, so sorting won't reorder the shared sample
+			procList := keep.apply(cached.Processes)
+			if !enableCPU {
+				procList = withoutCPU(procList)
+			}
+			return finishProcessList(procList, sortBy, limit, sampledAt.UnixMilli()), nil
+		}
+	}
+
+	return self.collectProcesses(sortBy, limit, enableCPU, cursor, keep)
+}
+
+// withoutCPU zeroes CPU usage as a collection without enableCPU would. The
+// sampler always measures it, so the processes are copied first.
+func withoutCPU(procList []*models.ProcessInfo) []*models.ProcessInfo {
+	for i, proc := range procList {
+		stripped := *proc
+		stripped.CPU = 0
+		procList[i] = &stripped
+	}
+	return procList
+}
+
+func (self *GopsUtil) collectProcesses(sortBy ProcSortBy, limit int, enableCPU bool, cursor string, keep *processMatcher) (*models.ProcessListResponse, error) {
 	procs, err := process.Processes()
 	if err != nil {
 		return nil, err
@@ -93,6 +131,18 @@ func (self *GopsUtil) GetProcessesWithCursor(sortBy ProcSortBy, limit int, enabl
 			currentCPUTime = times.User + times.System
 		}
 
+		// Only readable for our own processes unless running as root
+		var ioRead, ioWrite uint64
+		if io, err := p.IOCounters(); err == nil {
+			ioRead, ioWrite = io.ReadBytes, io.WriteBytes
+		}
+
+		var ioReadRate, ioWriteRate float64
+		if cursorData, hasCursor := cursorMap[p.Pid]; hasCursor {
+			ioReadRate = calculateProcessIORateWithCursor(cursorData.IOReadBytes, ioRead, cursorData.Timestamp, currentTime)
+			ioWriteRate = calculateProcessIORateWithCursor(cursorData.IOWriteBytes, ioWrite, cursorData.Timestamp, currentTime)
+		}
+
 		cpuPercent := 0.0
 		if enableCPU {
 			if cursorData, hasCursor := cursorMap[p.Pid]; hasCursor {
@@ -149,10 +199,48 @@ func (self *GopsUtil) GetProcessesWithCursor(sortBy ProcSortBy, limit int, enabl
 			Username:          username,
 			Command:           name,
 			FullCommand:       cmdline,
+			IOReadBytes:       ioRead,
+			IOWriteBytes:      ioWrite,
+			IOReadRate:        ioReadRate,
+			IOWriteRate:       ioWriteRate,
 		})
 	}
 
-	// Sort processes
+	return finishProcessList(keep.apply(procList), sortBy, limit, currentTime), nil
+}
+
+// finishProcessList sorts and truncates procList and builds the cursor for it
+func finishProcessList(procList []*models.ProcessInfo, sortBy ProcSortBy, limit int, currentTime int64) *models.ProcessListResponse {
+	sortProcesses(procList, sortBy)
+
+	// Limit to MaxProcs
+	if limit > 0 && len(procList) > limit {
+		procList = procList[:limit]
+	}
+
+	// Create cursor data for all processes
+	cursorList := make([]models.ProcessCursorData, 0, len(procList))
+	for _, proc := range procList {
+		cursorList = append(cursorList, models.ProcessCursorData{
+			PID:          proc.PID,
+			Ticks:        proc.PTicks,
+			Timestamp:    currentTime,
+			IOReadBytes:  proc.IOReadBytes,
+			IOWriteBytes: proc.IOWriteBytes,
+		})
+	}
+
+	// Encode cursor list as single base64 string
+	cursorBytes, _ := json.Marshal(cursorList)
+	cursorStr := base64.RawURLEncoding.EncodeToString(cursorBytes)
+
+	return &models.ProcessListResponse{
+		Processes: procList,
+		Cursor:    cursorStr,
+	}
+}
+
+func sortProcesses(procList []*models.ProcessInfo, sortBy ProcSortBy) {
 	switch sortBy {
 	case SortByCPU:
 		sort.Slice(procList, func(i, j int) bool {
@@ -170,35 +258,15 @@ func (self *GopsUtil) GetProcessesWithCursor(sortBy ProcSortBy, limit int, enabl
 		sort.Slice(procList, func(i, j int) bool {
 			return procList[i].PID < procList[j].PID
 		})
+	case SortByIO:
+		sort.Slice(procList, func(i, j int) bool {
+			return procList[i].IOReadRate+procList[i].IOWriteRate > procList[j].IOReadRate+procList[j].IOWriteRate
+		})
 	default:
 		sort.Slice(procList, func(i, j int) bool {
 			return procList[i].CPU > procList[j].CPU
 		})
 	}
-
-	// Limit to MaxProcs
-	if limit > 0 && len(procList) > limit {
-		procList = procList[:limit]
-	}
-
-	// Create cursor data for all processes
-	cursorList := make([]models.ProcessCursorData, 0, len(procList))
-	for _, proc := range procList {
-		cursorList = append(cursorList, models.ProcessCursorData{
-			PID:       proc.PID,
-			Ticks:     proc.PTicks,
-			Timestamp: currentTime,
-		})
-	}
-
-	// Encode cursor list as single base64 string
-	cursorBytes, _ := json.Marshal(cursorList)
-	cursorStr := base64.RawURLEncoding.EncodeToString(cursorBytes)
-
-	return &models.ProcessListResponse{
-		Processes: procList,
-		Cursor:    cursorStr,
-	}, nil
 }
 
 type ProcSortBy string
@@ -208,6 +276,7 @@ const (
 	SortByMemory ProcSortBy = "memory"
 	SortByName   ProcSortBy = "name"
 	SortByPID    ProcSortBy = "pid"
+	SortByIO     ProcSortBy = "io"
 )
 
 // Register enum in OpenAPI specification
@@ -221,6 +290,7 @@ func (u ProcSortBy) Schema(r huma.Registry) *huma.Schema {
 			string(SortByMemory),
 			string(SortByName),
 			string(SortByPID),
+			string(SortByIO),
 		}...)
 		r.Map()["ProcSortBy"] = schemaRef
 	}
@@ -250,3 +320,18 @@ func calculateProcessCPUPercentageWithCursor(cursor *models.ProcessCursorData, c
 
 	return cpuPercent
 }
+
+// calculateProcessIORateWithCursor returns bytes per second between the
+// cursor and now. A counter that went backwards means the pid was reused.
+func calculateProcessIORateWithCursor(prevBytes, currentBytes uint64, prevTime, currentTime int64) float64 {
+	if prevTime == 0 || currentBytes < prevBytes {
+		return 0
+	}
+
+	wallTimeDiff := float64(currentTime-prevTime) / 1000.0
+	if wallTimeDiff <= 0 {
+		return 0
+	}
+
+	return float64(currentBytes-prevBytes) / wallTimeDiff
+}
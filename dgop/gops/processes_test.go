@@ -0,0 +1,28 @@
+package gops
+
+import (
+	"testing"
+
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateProcessIORateWithCursor(t *testing.T) {
+	assert.InDelta(t, 1024.0, calculateProcessIORateWithCursor(1000, 3048, 1000, 3000), 0.001)
+
+	// No baseline, no elapsed time, or a reused pid
+	assert.Zero(t, calculateProcessIORateWithCursor(0, 4096, 0, 3000))
+	assert.Zero(t, calculateProcessIORateWithCursor(1000, 4096, 3000, 3000))
+	assert.Zero(t, calculateProcessIORateWithCursor(4096, 1000, 1000, 3000))
+}
+
+func TestSortProcessesByIO(t *testing.T) {
+	procs := []*models.ProcessInfo{
+		{PID: 1, IOReadRate: 100},
+		{PID: 2, IOReadRate: 50, IOWriteRate: 500},
+		{PID: 3},
+	}
+
+	sortProcesses(procs, SortByIO)
+	assert.Equal(t, []int32{2, 1, 3}, []int32{procs[0].PID, procs[1].PID, procs[2].PID})
+}
@@ -0,0 +1,103 @@
+package gops
+
+import (
+	"fmt"
+	"os/user"
+	"regexp"
+	"slices"
+
+	"github.com/AvengeMedia/dgop/errdefs"
+	"github.com/AvengeMedia/dgop/models"
+)
+
+// ProcessFilter narrows a process list before it is sorted and limited.
+// The zero value keeps every process.
+type ProcessFilter struct {
+	Usernames         []string
+	Command           string // Regular expression matched against the process name
+	Cmdline           string // Regular expression matched against the full command line
+	PIDs              []int32
+	MinCPU            float64
+	MinMemory         float32
+	HideKernelThreads bool
+	OnlyMine          bool
+}
+
+type processMatcher struct {
+	filter   ProcessFilter
+	command  *regexp.Regexp
+	cmdline  *regexp.Regexp
+	username string
+}
+
+// matcher compiles the filter. A nil *processMatcher keeps everything.
+func (f ProcessFilter) matcher() (*processMatcher, error) {
+	m := &processMatcher{filter: f}
+
+	var err error
+	if f.Command != "" {
+		if m.command, err = regexp.Compile(f.Command); err != nil {
+			return nil, errdefs.NewCustomError(errdefs.ErrTypeInvalidInput, fmt.Sprintf("invalid command pattern: %v", err))
+		}
+	}
+	if f.Cmdline != "" {
+		if m.cmdline, err = regexp.Compile(f.Cmdline); err != nil {
+			return nil, errdefs.NewCustomError(errdefs.ErrTypeInvalidInput, fmt.Sprintf("invalid cmdline pattern: %v", err))
+		}
+	}
+	if f.OnlyMine {
+		current, err := user.Current()
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine the current user: %w", err)
+		}
+		m.username = current.Username
+	}
+
+	return m, nil
+}
+
+func (m *processMatcher) match(proc *models.ProcessInfo) bool {
+	if m == nil {
+		return true
+	}
+	f := m.filter
+
+	if len(f.PIDs) > 0 && !slices.Contains(f.PIDs, proc.PID) {
+		return false
+	}
+	if len(f.Usernames) > 0 && !slices.Contains(f.Usernames, proc.Username) {
+		return false
+	}
+	if m.username != "" && proc.Username != m.username {
+		return false
+	}
+	if f.HideKernelThreads && isKernelThread(proc) {
+		return false
+	}
+	if m.command != nil && !m.command.MatchString(proc.Command) {
+		return false
+	}
+	if m.cmdline != nil && !m.cmdline.MatchString(proc.FullCommand) {
+		return false
+	}
+	if proc.CPU < f.MinCPU || proc.MemoryPercent < f.MinMemory {
+		return false
+	}
+	return true
+}
+
+// apply returns the matching processes in a new slice
+func (m *processMatcher) apply(procList []*models.ProcessInfo) []*models.ProcessInfo {
+	kept := make([]*models.ProcessInfo, 0, len(procList))
+	for _, proc := range procList {
+		if m.match(proc) {
+			kept = append(kept, proc)
+		}
+	}
+	return kept
+}
+
+// Kernel threads are kthreadd (pid 2) and its children
+func isKernelThread(proc *models.ProcessInfo) bool {
+	return proc.PID == 2 || proc.PPID == 2
+}
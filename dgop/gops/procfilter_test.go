@@ -0,0 +1,58 @@
+package gops
+
+import (
+	"testing"
+
+	"github.com/AvengeMedia/dgop/errdefs"
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func filterPIDs(t *testing.T, filter ProcessFilter, procs []*models.ProcessInfo) []int32 {
+	keep, err := filter.matcher()
+	require.NoError(t, err)
+
+	pids := make([]int32, 0)
+	for _, proc := range keep.apply(procs) {
+		pids = append(pids, proc.PID)
+	}
+	return pids
+}
+
+func TestProcessFilter(t *testing.T) {
+	procs := []*models.ProcessInfo{
+		{PID: 2, PPID: 0, Username: "root", Command: "kthreadd"},
+		{PID: 40, PPID: 2, Username: "root", Command: "kworker/0:1"},
+		{PID: 100, PPID: 1, Username: "alice", Command: "firefox", FullCommand: "/usr/lib/firefox/firefox --new-window", CPU: 12, MemoryPercent: 8},
+		{PID: 101, PPID: 100, Username: "alice", Command: "Web Content", FullCommand: "/usr/lib/firefox/firefox -contentproc", CPU: 3, MemoryPercent: 2},
+		{PID: 200, PPID: 1, Username: "bob", Command: "make", FullCommand: "make -j8", CPU: 40, MemoryPercent: 0.5},
+	}
+
+	assert.Equal(t, []int32{2, 40, 100, 101, 200}, filterPIDs(t, ProcessFilter{}, procs))
+	assert.Equal(t, []int32{100, 200}, filterPIDs(t, ProcessFilter{HideKernelThreads: true, MinCPU: 5}, procs))
+	assert.Equal(t, []int32{100, 101}, filterPIDs(t, ProcessFilter{Cmdline: "firefox"}, procs))
+	assert.Equal(t, []int32{100}, filterPIDs(t, ProcessFilter{Command: "^fire", Usernames: []string{"alice", "bob"}}, procs))
+	assert.Equal(t, []int32{101, 200}, filterPIDs(t, ProcessFilter{PIDs: []int32{101, 200, 999}}, procs))
+	assert.Equal(t, []int32{100}, filterPIDs(t, ProcessFilter{MinMemory: 5}, procs))
+}
+
+func TestProcessFilterInvalidPattern(t *testing.T) {
+	_, err := ProcessFilter{Command: "("}.matcher()
+	assert.ErrorIs(t, err, errdefs.ErrInvalidInput)
+}
+
+func TestProcessFilterBeforeLimit(t *testing.T) {
+	procs := []*models.ProcessInfo{
+		{PID: 1, Command: "busy", CPU: 90},
+		{PID: 2, Command: "busy", CPU: 80},
+		{PID: 3, Command: "wanted", CPU: 1},
+	}
+
+	keep, err := ProcessFilter{Command: "wanted"}.matcher()
+	require.NoError(t, err)
+
+	result := finishProcessList(keep.apply(procs), SortByCPU, 1, 0)
+	require.Len(t, result.Processes, 1)
+	assert.Equal(t, int32(3), result.Processes[0].PID)
+}
@@ -0,0 +1,148 @@
+package gops
+
+import (
+	"sort"
+
+	"github.com/AvengeMedia/dgop/models"
+)
+
+// GetProcessTree nests the processes matching filter. A matching process whose
+// parent was filtered out becomes a root.
+func (self *GopsUtil) GetProcessTree(sortBy ProcSortBy, limit int, enableCPU bool, cursor string, filter ProcessFilter) (*models.ProcessTreeResponse, error) {
+	// The whole process list is needed to nest and total the subtrees
+	result, err := self.GetFilteredProcesses(sortBy, 0, enableCPU, cursor, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ProcessTreeResponse{
+		Processes: BuildProcessTree(result.Processes, sortBy, limit),
+		Cursor:    result.Cursor,
+	}, nil
+}
+
+// BuildProcessTree nests procs under their parents. Processes whose parent is
+// not in procs become roots. Siblings are sorted by sortBy, using the subtree
+// totals for cpu and memory so the heaviest branch comes first.
+//
+// With a limit, only the top limit processes by sortBy and their ancestors
+// are kept. Subtree totals still cover every descendant.
+func BuildProcessTree(procs []*models.ProcessInfo, sortBy ProcSortBy, limit int) []*models.ProcessTreeNode {
+	nodes := make(map[int32]*models.ProcessTreeNode, len(procs))
+	for _, proc := range procs {
+		nodes[proc.PID] = &models.ProcessTreeNode{ProcessInfo: proc}
+	}
+
+	roots := make([]*models.ProcessTreeNode, 0)
+	for _, proc := range procs {
+		node := nodes[proc.PID]
+		parent, ok := nodes[proc.PPID]
+		if !ok || proc.PPID == proc.PID {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	for _, root := range roots {
+		sumSubtree(root)
+	}
+
+	if limit > 0 && len(procs) > limit {
+		top := make([]*models.ProcessInfo, len(procs))
+		copy(top, procs)
+		sortProcesses(top, sortBy)
+
+		keep := make(map[int32]bool, limit)
+		for _, proc := range top[:limit] {
+			// Walk up to the root so the kept process stays reachable
+			for pid := proc.PID; !keep[pid]; {
+				keep[pid] = true
+				node, ok := nodes[pid]
+				if !ok || node.PPID == pid {
+					break
+				}
+				pid = node.PPID
+			}
+		}
+		roots = pruneTree(roots, keep)
+	}
+
+	sortTree(roots, sortBy)
+	return roots
+}
+
+func sumSubtree(node *models.ProcessTreeNode) {
+	node.SubtreeCPU = node.CPU
+	node.SubtreeMemoryKB = node.MemoryKB
+	node.SubtreeMemoryPercent = node.MemoryPercent
+
+	for _, child := range node.Children {
+		sumSubtree(child)
+		node.SubtreeCPU += child.SubtreeCPU
+		node.SubtreeMemoryKB += child.SubtreeMemoryKB
+		node.SubtreeMemoryPercent += child.SubtreeMemoryPercent
+	}
+}
+
+func pruneTree(nodes []*models.ProcessTreeNode, keep map[int32]bool) []*models.ProcessTreeNode {
+	kept := make([]*models.ProcessTreeNode, 0, len(nodes))
+	for _, node := range nodes {
+		if !keep[node.PID] {
+			continue
+		}
+		node.Children = pruneTree(node.Children, keep)
+		kept = append(kept, node)
+	}
+	return kept
+}
+
+func sortTree(nodes []*models.ProcessTreeNode, sortBy ProcSortBy) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		switch sortBy {
+		case SortByMemory:
+			return nodes[i].SubtreeMemoryKB > nodes[j].SubtreeMemoryKB
+		case SortByName:
+			return nodes[i].Command < nodes[j].Command
+		case SortByPID:
+			return nodes[i].PID < nodes[j].PID
+		case SortByIO:
+			return nodes[i].IOReadRate+nodes[i].IOWriteRate > nodes[j].IOReadRate+nodes[j].IOWriteRate
+		default:
+			return nodes[i].SubtreeCPU > nodes[j].SubtreeCPU
+		}
+	})
+
+	for _, node := range nodes {
+		sortTree(node.Children, sortBy)
+	}
+}
+
+// ProcessTreeRow is a tree node with the box drawing prefix for its depth,
+// for rendering the tree as a table
+type ProcessTreeRow struct {
+	Node   *models.ProcessTreeNode
+	Prefix string
+}
+
+// FlattenProcessTree lists the tree depth first
+func FlattenProcessTree(roots []*models.ProcessTreeNode) []ProcessTreeRow {
+	rows := make([]ProcessTreeRow, 0, len(roots))
+	for _, root := range roots {
+		rows = append(rows, ProcessTreeRow{Node: root})
+		rows = flattenChildren(rows, root.Children, "")
+	}
+	return rows
+}
+
+func flattenChildren(rows []ProcessTreeRow, nodes []*models.ProcessTreeNode, indent string) []ProcessTreeRow {
+	for i, node := range nodes {
+		branch, childIndent := "├─ ", indent+"│  "
+		if i == len(nodes)-1 {
+			branch, childIndent = "└─ ", indent+"   "
+		}
+		rows = append(rows, ProcessTreeRow{Node: node, Prefix: indent + branch})
+		rows = flattenChildren(rows, node.Children, childIndent)
+	}
+	return rows
+}
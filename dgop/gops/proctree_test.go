@@ -0,0 +1,63 @@
+package gops
+
+import (
+	"testing"
+
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProcs() []*models.ProcessInfo {
+	return []*models.ProcessInfo{
+		{PID: 1, PPID: 0, CPU: 0.1, MemoryKB: 100, Command: "init"},
+		{PID: 10, PPID: 1, CPU: 1, MemoryKB: 1000, Command: "firefox"},
+		{PID: 11, PPID: 10, CPU: 20, MemoryKB: 5000, Command: "tab"},
+		{PID: 12, PPID: 10, CPU: 5, MemoryKB: 3000, Command: "tab"},
+		{PID: 20, PPID: 1, CPU: 15, MemoryKB: 200, Command: "make"},
+		{PID: 30, PPID: 99, CPU: 0, MemoryKB: 10, Command: "orphan"},
+	}
+}
+
+func TestBuildProcessTree(t *testing.T) {
+	roots := BuildProcessTree(testProcs(), SortByCPU, 0)
+
+	assert.Len(t, roots, 2)
+	assert.Equal(t, int32(1), roots[0].PID)
+	assert.Equal(t, int32(30), roots[1].PID)
+
+	// firefox outranks make on subtree cpu even though make uses more itself
+	assert.Equal(t, int32(10), roots[0].Children[0].PID)
+	assert.InDelta(t, 26.0, roots[0].Children[0].SubtreeCPU, 0.001)
+	assert.Equal(t, uint64(9000), roots[0].Children[0].SubtreeMemoryKB)
+	assert.Equal(t, uint64(9300), roots[0].SubtreeMemoryKB)
+}
+
+func TestBuildProcessTreeLimit(t *testing.T) {
+	roots := BuildProcessTree(testProcs(), SortByCPU, 1)
+
+	// Only the busiest process and its ancestors remain
+	var pids []int32
+	for _, row := range FlattenProcessTree(roots) {
+		pids = append(pids, row.Node.PID)
+	}
+	assert.Equal(t, []int32{1, 10, 11}, pids)
+
+	// Totals still include the pruned siblings
+	assert.InDelta(t, 41.1, roots[0].SubtreeCPU, 0.001)
+}
+
+func TestFlattenProcessTree(t *testing.T) {
+	var prefixes []string
+	for _, row := range FlattenProcessTree(BuildProcessTree(testProcs(), SortByPID, 0)) {
+		prefixes = append(prefixes, row.Prefix+row.Node.Command)
+	}
+
+	assert.Equal(t, []string{
+		"init",
+		"├─ firefox",
+		"│  ├─ tab",
+		"│  └─ tab",
+		"└─ make",
+		"orphan",
+	}, prefixes)
+}
@@ -0,0 +1,312 @@
+package gops
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/disk"
+	"github.com/shirou/gopsutil/v4/net"
+)
+
+// PrometheusContentType is the content type of the text exposition format
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type metricType string
+
+const (
+	metricCounter metricType = "counter"
+	metricGauge   metricType = "gauge"
+)
+
+type metricFamily struct {
+	name    string
+	help    string
+	typ     metricType
+	samples []metricSample
+}
+
+type metricSample struct {
+	labels []string // key, value pairs
+	value  float64
+}
+
+func (f *metricFamily) add(value float64, labels ...string) {
+	f.samples = append(f.samples, metricSample{labels: labels, value: value})
+}
+
+// WritePrometheusMetrics writes every collector in the Prometheus text format.
+// Counters are the raw monotonic kernel values so rate() works on them.
+func (self *GopsUtil) WritePrometheusMetrics(w io.Writer) error {
+	var families []*metricFamily
+	families = append(families, self.cpuMetrics()...)
+	families = append(families, self.memoryMetrics()...)
+	families = append(families, self.networkMetrics()...)
+	families = append(families, self.diskMetrics()...)
+	families = append(families, self.mountMetrics()...)
+	families = append(families, self.temperatureMetrics()...)
+	families = append(families, self.gpuMetrics()...)
+
+	for _, f := range families {
+		if err := writeMetricFamily(w, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var cpuModes = []string{"user", "nice", "system", "idle", "iowait", "irq", "softirq", "steal"}
+
+func cpuModeValues(t cpu.TimesStat) []float64 {
+	return []float64{t.User, t.Nice, t.System, t.Idle, t.Iowait, t.Irq, t.Softirq, t.Steal}
+}
+
+func (self *GopsUtil) cpuMetrics() []*metricFamily {
+	total := &metricFamily{name: "dgop_cpu_seconds_total", help: "Seconds the CPUs spent in each mode.", typ: metricCounter}
+	core := &metricFamily{name: "dgop_cpu_core_seconds_total", help: "Seconds each CPU core spent in each mode.", typ: metricCounter}
+	freq := &metricFamily{name: "dgop_cpu_frequency_mhz", help: "Current CPU frequency in MHz.", typ: metricGauge}
+	temp := &metricFamily{name: "dgop_cpu_temperature_celsius", help: "CPU package temperature.", typ: metricGauge}
+
+	if times, err := cpu.Times(false); err == nil && len(times) > 0 {
+		for i, v := range cpuModeValues(times[0]) {
+			total.add(v, "mode", cpuModes[i])
+		}
+	}
+
+	if times, err := cpu.Times(true); err == nil {
+		for _, t := range times {
+			id := strings.TrimPrefix(t.CPU, "cpu")
+			for i, v := range cpuModeValues(t) {
+				core.add(v, "core", id, "mode", cpuModes[i])
+			}
+		}
+	}
+
+	if f := getCurrentCPUFreq(); f > 0 {
+		freq.add(f)
+	}
+
+	cpuTracker.mu.Lock()
+	t := getCPUTemperatureCached()
+	cpuTracker.mu.Unlock()
+	if t > 0 {
+		temp.add(t)
+	}
+
+	return []*metricFamily{total, core, freq, temp}
+}
+
+func (self *GopsUtil) memoryMetrics() []*metricFamily {
+	mem, err := self.GetMemoryInfo()
+	if err != nil {
+		return nil
+	}
+
+	gauge := func(name, help string, kb uint64) *metricFamily {
+		f := &metricFamily{name: name, help: help, typ: metricGauge}
+		f.add(float64(kb * 1024))
+		return f
+	}
+
+	return []*metricFamily{
+		gauge("dgop_memory_total_bytes", "Total usable memory.", mem.Total),
+		gauge("dgop_memory_free_bytes", "Unused memory.", mem.Free),
+		gauge("dgop_memory_available_bytes", "Memory available for new allocations.", mem.Available),
+		gauge("dgop_memory_buffers_bytes", "Memory used by kernel buffers.", mem.Buffers),
+		gauge("dgop_memory_cached_bytes", "Memory used by the page cache.", mem.Cached),
+		gauge("dgop_memory_shared_bytes", "Shared memory.", mem.Shared),
+		gauge("dgop_swap_total_bytes", "Total swap space.", mem.SwapTotal),
+		gauge("dgop_swap_free_bytes", "Unused swap space.", mem.SwapFree),
+	}
+}
+
+func (self *GopsUtil) networkMetrics() []*metricFamily {
+	rx := &metricFamily{name: "dgop_network_receive_bytes_total", help: "Bytes received per interface.", typ: metricCounter}
+	tx := &metricFamily{name: "dgop_network_transmit_bytes_total", help: "Bytes transmitted per interface.", typ: metricCounter}
+	rxPackets := &metricFamily{name: "dgop_network_receive_packets_total", help: "Packets received per interface.", typ: metricCounter}
+	txPackets := &metricFamily{name: "dgop_network_transmit_packets_total", help: "Packets transmitted per interface.", typ: metricCounter}
+	rxErrs := &metricFamily{name: "dgop_network_receive_errors_total", help: "Receive errors per interface.", typ: metricCounter}
+	txErrs := &metricFamily{name: "dgop_network_transmit_errors_total", help: "Transmit errors per interface.", typ: metricCounter}
+	rxDrops := &metricFamily{name: "dgop_network_receive_drops_total", help: "Dropped inbound packets per interface.", typ: metricCounter}
+	txDrops := &metricFamily{name: "dgop_network_transmit_drops_total", help: "Dropped outbound packets per interface.", typ: metricCounter}
+
+	netIO, err := net.IOCounters(true)
+	if err != nil {
+		return nil
+	}
+
+	// The configured filter was checked when it was set
+	match, _ := self.interfaceFilter(DeviceFilter{})
+	for _, n := range netIO {
+		if !match.Match(n.Name) {
+			continue
+		}
+		rx.add(float64(n.BytesRecv), "interface", n.Name)
+		tx.add(float64(n.BytesSent), "interface", n.Name)
+		rxPackets.add(float64(n.PacketsRecv), "interface", n.Name)
+		txPackets.add(float64(n.PacketsSent), "interface", n.Name)
+		rxErrs.add(float64(n.Errin), "interface", n.Name)
+		txErrs.add(float64(n.Errout), "interface", n.Name)
+		rxDrops.add(float64(n.Dropin), "interface", n.Name)
+		txDrops.add(float64(n.Dropout), "interface", n.Name)
+	}
+
+	return []*metricFamily{rx, tx, rxPackets, txPackets, rxErrs, txErrs, rxDrops, txDrops}
+}
+
+func (self *GopsUtil) diskMetrics() []*metricFamily {
+	readBytes := &metricFamily{name: "dgop_disk_read_bytes_total", help: "Bytes read per device.", typ: metricCounter}
+	writeBytes := &metricFamily{name: "dgop_disk_written_bytes_total", help: "Bytes written per device.", typ: metricCounter}
+	reads := &metricFamily{name: "dgop_disk_reads_completed_total", help: "Reads completed per device.", typ: metricCounter}
+	writes := &metricFamily{name: "dgop_disk_writes_completed_total", help: "Writes completed per device.", typ: metricCounter}
+	ioTime := &metricFamily{name: "dgop_disk_io_time_seconds_total", help: "Seconds spent doing I/O per device.", typ: metricCounter}
+
+	diskIO, err := disk.IOCounters()
+	if err != nil {
+		return nil
+	}
+
+	match, _ := self.diskFilter(DeviceFilter{})
+	for name, d := range diskIO {
+		if !match.Match(name) {
+			continue
+		}
+		readBytes.add(float64(d.ReadBytes), "device", name)
+		writeBytes.add(float64(d.WriteBytes), "device", name)
+		reads.add(float64(d.ReadCount), "device", name)
+		writes.add(float64(d.WriteCount), "device", name)
+		ioTime.add(float64(d.IoTime)/1000.0, "device", name)
+	}
+
+	return []*metricFamily{readBytes, writeBytes, reads, writes, ioTime}
+}
+
+func (self *GopsUtil) mountMetrics() []*metricFamily {
+	size := &metricFamily{name: "dgop_filesystem_size_bytes", help: "Filesystem size.", typ: metricGauge}
+	used := &metricFamily{name: "dgop_filesystem_used_bytes", help: "Filesystem space in use.", typ: metricGauge}
+	avail := &metricFamily{name: "dgop_filesystem_avail_bytes", help: "Filesystem space available to unprivileged users.", typ: metricGauge}
+
+	partitions, err := disk.Partitions(false)
+	if err != nil {
+		return nil
+	}
+
+	for _, p := range partitions {
+		// Skip tmpfs and devtmpfs, like GetDiskMounts
+		if slices.Contains(DefaultSkippedFSTypes, p.Fstype) {
+			continue
+		}
+
+		usage, err := disk.Usage(p.Mountpoint)
+		if err != nil {
+			continue
+		}
+
+		labels := []string{"device", p.Device, "mount", p.Mountpoint, "fstype", p.Fstype}
+		size.add(float64(usage.Total), labels...)
+		used.add(float64(usage.Used), labels...)
+		avail.add(float64(usage.Free), labels...)
+	}
+
+	return []*metricFamily{size, used, avail}
+}
+
+func (self *GopsUtil) temperatureMetrics() []*metricFamily {
+	temp := &metricFamily{name: "dgop_temperature_celsius", help: "Temperature sensor reading.", typ: metricGauge}
+	high := &metricFamily{name: "dgop_temperature_high_celsius", help: "Temperature sensor high threshold.", typ: metricGauge}
+	crit := &metricFamily{name: "dgop_temperature_critical_celsius", help: "Temperature sensor critical threshold.", typ: metricGauge}
+
+	chips, err := self.GetTemperatures()
+	if err != nil {
+		return nil
+	}
+
+	// sensor alone repeats, two NVMe drives are both nvme_composite and a
+	// chip without labels names every input after itself
+	for _, chip := range chips {
+		for _, s := range chip.Sensors {
+			labels := []string{"sensor", s.Name, "chip", chip.Name, "device", chip.Device, "label", s.Label}
+			temp.add(s.Temperature, labels...)
+			if s.High > 0 {
+				high.add(s.High, labels...)
+			}
+			if s.Critical > 0 {
+				crit.add(s.Critical, labels...)
+			}
+		}
+	}
+
+	return []*metricFamily{temp, high, crit}
+}
+
+func (self *GopsUtil) gpuMetrics() []*metricFamily {
+	temp := &metricFamily{name: "dgop_gpu_temperature_celsius", help: "GPU temperature.", typ: metricGauge}
+
+	gpuEntries, err := self.detectGPUEntries()
+	if err != nil {
+		return nil
+	}
+
+	// Labelled by BDF as well, two cards of the same model share a PCI ID
+	var nvidia map[string]*nvidiaGPU
+	for i, gpu := range gpusFromEntries(gpuEntries) {
+		if gpu.PciId == "" {
+			continue
+		}
+		entry := gpuEntries[i]
+		if entry.Driver == "nvidia" && nvidia == nil {
+			nvidia = self.queryNvidia(gpuEntries)
+		}
+		temperature, hwmon := gpuTemperature(entry, gpu.PciId, nvidia)
+		if hwmon == "unknown" {
+			continue
+		}
+		temp.add(temperature, "pci_id", gpu.PciId, "bdf", entry.BDF, "driver", gpu.Driver, "name", gpu.FullName)
+	}
+
+	return []*metricFamily{temp}
+}
+
+func writeMetricFamily(w io.Writer, f *metricFamily) error {
+	if len(f.samples) == 0 {
+		return nil
+	}
+
+	// Stable output makes scrapes diffable
+	sort.SliceStable(f.samples, func(i, j int) bool {
+		return strings.Join(f.samples[i].labels, "\x00") < strings.Join(f.samples[j].labels, "\x00")
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# HELP %s %s\n", f.name, f.help)
+	fmt.Fprintf(&b, "# TYPE %s %s\n", f.name, f.typ)
+	for _, s := range f.samples {
+		b.WriteString(f.name)
+		if len(s.labels) > 0 {
+			b.WriteString("{")
+			for i := 0; i+1 < len(s.labels); i += 2 {
+				if i > 0 {
+					b.WriteString(",")
+				}
+				fmt.Fprintf(&b, "%s=\"%s\"", s.labels[i], escapeLabelValue(s.labels[i+1]))
+			}
+			b.WriteString("}")
+		}
+		b.WriteString(" ")
+		b.WriteString(strconv.FormatFloat(s.value, 'g', -1, 64))
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelValueEscaper.Replace(v)
+}
@@ -0,0 +1,79 @@
+package gops
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteMetricFamily(t *testing.T) {
+	f := &metricFamily{name: "dgop_test_total", help: "A test counter.", typ: metricCounter}
+	f.add(2, "sensor", "b")
+	f.add(1.5, "sensor", `a "quoted"\path`+"\n")
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeMetricFamily(&buf, f))
+
+	expected := "# HELP dgop_test_total A test counter.\n" +
+		"# TYPE dgop_test_total counter\n" +
+		`dgop_test_total{sensor="a \"quoted\"\\path\n"} 1.5` + "\n" +
+		`dgop_test_total{sensor="b"} 2` + "\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteMetricFamilySkipsEmpty(t *testing.T) {
+	f := &metricFamily{name: "dgop_empty", help: "Nothing.", typ: metricGauge}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeMetricFamily(&buf, f))
+	assert.Empty(t, buf.String())
+}
+
+func TestTemperatureMetricsAreUnique(t *testing.T) {
+	root := t.TempDir()
+	for i, device := range []string{"nvme0", "nvme1"} {
+		hwmon := fmt.Sprintf("hwmon%d", i)
+		writeSysfsAttrs(t, filepath.Join(root, "class", "hwmon", hwmon), map[string]string{
+			"name":        "nvme",
+			"temp1_input": "41000",
+			"temp1_label": "Composite",
+			"temp1_crit":  "85000",
+		})
+		deviceDir := filepath.Join(root, "devices", "nvme", device)
+		require.NoError(t, os.MkdirAll(deviceDir, 0o755))
+		require.NoError(t, os.Symlink(deviceDir, filepath.Join(root, "class", "hwmon", hwmon, "device")))
+	}
+	// Unlabelled inputs all get the chip name as sensor
+	writeSysfsAttrs(t, filepath.Join(root, "class", "hwmon", "hwmon2"), map[string]string{
+		"name":        "acpitz",
+		"temp1_input": "30000",
+		"temp2_input": "32000",
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, (&GopsUtil{sysRoot: root}).WritePrometheusMetrics(&buf))
+
+	seen := make(map[string]bool)
+	var temps []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		series, _, _ := strings.Cut(line, " ")
+		assert.False(t, seen[series], "duplicate series %s", series)
+		seen[series] = true
+		if strings.HasPrefix(series, "dgop_temperature_celsius{") {
+			temps = append(temps, series)
+		}
+	}
+
+	assert.Len(t, temps, 4)
+	assert.Contains(t, temps, `dgop_temperature_celsius{sensor="nvme_composite",chip="nvme",device="nvme1",label="Composite"}`)
+	assert.Contains(t, temps, `dgop_temperature_celsius{sensor="acpitz",chip="acpitz",device="",label="temp2"}`)
+}
@@ -0,0 +1,158 @@
+package gops
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/AvengeMedia/dgop/internal/log"
+	"github.com/AvengeMedia/dgop/models"
+)
+
+const (
+	DefaultSampleInterval     = time.Second
+	DefaultProcSampleInterval = 2 * time.Second
+)
+
+// SamplerConfig controls how often each module is collected in the background
+type SamplerConfig struct {
+	Interval     time.Duration // cpu, net-rate and disk-rate
+	ProcInterval time.Duration // processes, which are much more expensive
+}
+
+// Sampler collects the cursor based modules on a timer so callers without a
+// cursor get accurate rates immediately. Each module chains its own cursor
+// from one sample to the next.
+type Sampler struct {
+	cfg SamplerConfig
+
+	cpu      latestSample[*models.CPUInfo]
+	procs    latestSample[*models.ProcessListResponse]
+	netRate  latestSample[*models.NetworkRateResponse]
+	diskRate latestSample[*models.DiskRateResponse]
+}
+
+// StartSampler starts collecting in the background until ctx is done. Once
+// started, the Get*WithCursor methods answer from the latest sample when
+// called without a cursor. It must be called before the GopsUtil is shared.
+func (self *GopsUtil) StartSampler(ctx context.Context, cfg SamplerConfig) *Sampler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultSampleInterval
+	}
+	if cfg.ProcInterval <= 0 {
+		cfg.ProcInterval = DefaultProcSampleInterval
+	}
+
+	s := &Sampler{cfg: cfg}
+
+	go runSampleLoop(ctx, "cpu", cfg.Interval, &s.cpu, func(prev *models.CPUInfo) (*models.CPUInfo, error) {
+		return self.collectCPUInfo(cursorOf(prev, func(v *models.CPUInfo) string { return v.Cursor }))
+	})
+	go runSampleLoop(ctx, "processes", cfg.ProcInterval, &s.procs, func(prev *models.ProcessListResponse) (*models.ProcessListResponse, error) {
+		// Keep every process, sorting and limiting happen per request
+		return self.collectProcesses(SortByPID, 0, true, cursorOf(prev, func(v *models.ProcessListResponse) string { return v.Cursor }), nil)
+	})
+	go runSampleLoop(ctx, "net-rate", cfg.Interval, &s.netRate, func(prev *models.NetworkRateResponse) (*models.NetworkRateResponse, error) {
+		return self.collectNetworkRates(cursorOf(prev, func(v *models.NetworkRateResponse) string { return v.Cursor }))
+	})
+	go runSampleLoop(ctx, "disk-rate", cfg.Interval, &s.diskRate, func(prev *models.DiskRateResponse) (*models.DiskRateResponse, error) {
+		return self.collectDiskRates(cursorOf(prev, func(v *models.DiskRateResponse) string { return v.Cursor }))
+	})
+
+	self.sampler = s
+	return s
+}
+
+// A sample older than this many intervals means the loop is stuck, so
+// callers fall back to collecting themselves
+const staleSampleIntervals = 3
+
+func (self *Sampler) latestCPU() (*models.CPUInfo, bool) {
+	if self == nil {
+		return nil, false
+	}
+	v, _, ok := self.cpu.get(staleSampleIntervals * self.cfg.Interval)
+	return v, ok
+}
+
+func (self *Sampler) latestProcesses() (*models.ProcessListResponse, time.Time, bool) {
+	if self == nil {
+		return nil, time.Time{}, false
+	}
+	return self.procs.get(staleSampleIntervals * self.cfg.ProcInterval)
+}
+
+func (self *Sampler) latestNetworkRates() (*models.NetworkRateResponse, bool) {
+	if self == nil {
+		return nil, false
+	}
+	v, _, ok := self.netRate.get(staleSampleIntervals * self.cfg.Interval)
+	return v, ok
+}
+
+func (self *Sampler) latestDiskRates() (*models.DiskRateResponse, bool) {
+	if self == nil {
+		return nil, false
+	}
+	v, _, ok := self.diskRate.get(staleSampleIntervals * self.cfg.Interval)
+	return v, ok
+}
+
+func cursorOf[T any](prev *T, cursor func(*T) string) string {
+	if prev == nil {
+		return ""
+	}
+	return cursor(prev)
+}
+
+func runSampleLoop[T any](ctx context.Context, name string, interval time.Duration, latest *latestSample[*T], collect func(prev *T) (*T, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var prev *T
+	for {
+		data, err := collect(prev)
+		if err != nil {
+			log.Warnf("Sampler failed to collect %s: %v", name, err)
+		} else {
+			latest.set(data)
+			prev = data
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// latestSample holds the newest sample of one module. Only the newest is
+// kept, the cursor inside it already covers the time since the last one.
+// This used to be a ring buffer of recent samples, but no handler read past
+// the newest, so the history went until something needs it.
+type latestSample[T any] struct {
+	mu   sync.RWMutex
+	time time.Time
+	data T
+	ok   bool
+}
+
+func (s *latestSample[T]) set(data T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.time, s.data, s.ok = time.Now(), data, true
+}
+
+// get returns the sample if there is one no older than maxAge
+func (s *latestSample[T]) get(maxAge time.Duration) (T, time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var zero T
+	if !s.ok || time.Since(s.time) > maxAge {
+		return zero, time.Time{}, false
+	}
+	return s.data, s.time, true
+}
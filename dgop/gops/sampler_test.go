@@ -0,0 +1,56 @@
+package gops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestSample(t *testing.T) {
+	var s latestSample[int]
+
+	_, _, ok := s.get(time.Minute)
+	assert.False(t, ok)
+
+	s.set(1)
+	s.set(2)
+	v, _, ok := s.get(time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+}
+
+func TestLatestSampleStale(t *testing.T) {
+	var s latestSample[int]
+	s.set(1)
+	s.time = time.Now().Add(-time.Minute)
+
+	_, _, ok := s.get(time.Second)
+	assert.False(t, ok)
+}
+
+func TestCachedProcessesHonourEnableCPU(t *testing.T) {
+	sampler := &Sampler{cfg: SamplerConfig{ProcInterval: time.Minute}}
+	shared := &models.ProcessInfo{PID: 10, CPU: 42, MemoryPercent: 1}
+	sampler.procs.set(&models.ProcessListResponse{Processes: []*models.ProcessInfo{shared}})
+	gopsUtil := &GopsUtil{sampler: sampler}
+
+	withCPU, err := gopsUtil.GetProcesses(SortByPID, 0, true)
+	require.NoError(t, err)
+	assert.Equal(t, 42.0, withCPU.Processes[0].CPU)
+
+	noCPU, err := gopsUtil.GetProcesses(SortByPID, 0, false)
+	require.NoError(t, err)
+	assert.Zero(t, noCPU.Processes[0].CPU)
+	assert.Equal(t, 42.0, shared.CPU, "the shared sample is left alone")
+}
+
+func TestNilSamplerFallsThrough(t *testing.T) {
+	var s *Sampler
+	_, ok := s.latestCPU()
+	assert.False(t, ok)
+	_, _, ok = s.latestProcesses()
+	assert.False(t, ok)
+}
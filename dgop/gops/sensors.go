@@ -0,0 +1,170 @@
+package gops
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AvengeMedia/dgop/models"
+)
+
+type sensorType struct {
+	name  string
+	unit  string
+	scale float64 // Divides the raw sysfs value
+	order int
+}
+
+// See Documentation/hwmon/sysfs-interface.rst
+var sensorTypes = map[string]sensorType{
+	"temp":  {name: "temp", unit: "°C", scale: 1000, order: 0},
+	"fan":   {name: "fan", unit: "RPM", scale: 1, order: 1},
+	"in":    {name: "in", unit: "V", scale: 1000, order: 2},
+	"power": {name: "power", unit: "W", scale: 1e6, order: 3},
+	"curr":  {name: "curr", unit: "A", scale: 1000, order: 4},
+}
+
+var sensorAttr = regexp.MustCompile(`^(temp|fan|in|power|curr)(\d+)_([a-z_]+)$`)
+
+// GetSensors reads every hwmon chip, sorted by chip name
+func (self *GopsUtil) GetSensors() ([]*models.SensorChip, error) {
+	hwmonDir := self.sysPath("class", "hwmon")
+	entries, err := os.ReadDir(hwmonDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make([]*models.SensorChip, 0), nil
+		}
+		return nil, err
+	}
+
+	chips := make([]*models.SensorChip, 0, len(entries))
+	for _, entry := range entries {
+		chip := readSensorChip(filepath.Join(hwmonDir, entry.Name()))
+		if len(chip.Readings) > 0 {
+			chips = append(chips, chip)
+		}
+	}
+
+	sort.SliceStable(chips, func(i, j int) bool {
+		if chips[i].Name != chips[j].Name {
+			return chips[i].Name < chips[j].Name
+		}
+		return chips[i].Device < chips[j].Device
+	})
+	return chips, nil
+}
+
+func readSensorChip(dir string) *models.SensorChip {
+	chip := &models.SensorChip{
+		Name:  readSysfsString(filepath.Join(dir, "name")),
+		Hwmon: filepath.Base(dir),
+	}
+	if target, err := filepath.EvalSymlinks(filepath.Join(dir, "device")); err == nil {
+		chip.Device = filepath.Base(target)
+	}
+
+	chip.Readings = readSensorReadings(dir)
+	// Older drivers keep their attributes on the parent device
+	if len(chip.Readings) == 0 {
+		chip.Readings = readSensorReadings(filepath.Join(dir, "device"))
+		if chip.Name == "" {
+			chip.Name = readSysfsString(filepath.Join(dir, "device", "name"))
+		}
+	}
+	if chip.Name == "" {
+		chip.Name = chip.Hwmon
+	}
+
+	return chip
+}
+
+func readSensorReadings(dir string) []*models.SensorReading {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	readings := make(map[string]*models.SensorReading)
+	hasValue := make(map[string]bool)
+	for _, entry := range entries {
+		match := sensorAttr.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		st := sensorTypes[match[1]]
+		key := match[1] + match[2]
+		reading, ok := readings[key]
+		if !ok {
+			reading = &models.SensorReading{Type: st.name, Key: key, Label: key, Unit: st.unit}
+			readings[key] = reading
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		attr := match[3]
+		if attr == "label" {
+			if label := readSysfsString(path); label != "" {
+				reading.Label = label
+			}
+			continue
+		}
+
+		raw, ok := readSysfsInt(path)
+		if !ok {
+			continue
+		}
+		value := float64(raw) / st.scale
+
+		switch attr {
+		case "input":
+			reading.Value = value
+			hasValue[key] = true
+		case "average":
+			// Power meters without an instantaneous reading
+			if !hasValue[key] {
+				reading.Value = value
+				hasValue[key] = true
+			}
+		case "min":
+			reading.Min = value
+		case "max":
+			reading.Max = value
+		case "crit":
+			reading.Critical = value
+		case "lcrit":
+			reading.LowCrit = value
+		case "emergency":
+			reading.Emergency = value
+		case "alarm", "fault":
+			if raw != 0 {
+				reading.Alarms = append(reading.Alarms, attr)
+			}
+		case "min_alarm", "max_alarm", "crit_alarm", "lcrit_alarm", "emergency_alarm":
+			if raw != 0 {
+				reading.Alarms = append(reading.Alarms, strings.TrimSuffix(attr, "_alarm"))
+			}
+		}
+	}
+
+	result := make([]*models.SensorReading, 0, len(readings))
+	for key, reading := range readings {
+		if hasValue[key] {
+			sort.Strings(reading.Alarms)
+			result = append(result, reading)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		oi, oj := sensorTypes[result[i].Type].order, sensorTypes[result[j].Type].order
+		if oi != oj {
+			return oi < oj
+		}
+		ni, _ := strconv.Atoi(strings.TrimPrefix(result[i].Key, result[i].Type))
+		nj, _ := strconv.Atoi(strings.TrimPrefix(result[j].Key, result[j].Type))
+		return ni < nj
+	})
+	return result
+}
@@ -0,0 +1,119 @@
+package gops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sensorsFixture(t *testing.T) string {
+	root := t.TempDir()
+	writeSysfsAttrs(t, filepath.Join(root, "class", "hwmon", "hwmon1"), map[string]string{
+		"name":              "nct6798",
+		"temp2_input":       "45500",
+		"temp2_label":       "SYSTIN",
+		"temp2_max":         "80000",
+		"temp2_crit":        "100000",
+		"temp10_input":      "38000",
+		"fan1_input":        "1200",
+		"fan1_min":          "300",
+		"fan1_alarm":        "0",
+		"fan2_input":        "0",
+		"fan2_min":          "300",
+		"fan2_alarm":        "1",
+		"in0_input":         "1032",
+		"in0_label":         "Vcore",
+		"in0_min":           "900",
+		"in0_max":           "1500",
+		"in0_max_alarm":     "1",
+		"curr1_input":       "2500",
+		"temp3_label":       "no input",
+		"pwm1":              "128",
+		"power1_average":    "35000000",
+		"power1_cap":        "65000000",
+		"power1_input":      "40000000",
+		"power1_crit":       "95000000",
+		"power1_crit_alarm": "0",
+	})
+	writeSysfsAttrs(t, filepath.Join(root, "class", "hwmon", "hwmon0"), map[string]string{
+		"name":        "coretemp",
+		"temp1_input": "51000",
+		"temp1_label": "Package id 0",
+		"temp1_crit":  "105000",
+	})
+	// Readings kept on the parent device, as old drivers do
+	writeSysfsAttrs(t, filepath.Join(root, "class", "hwmon", "hwmon2"), map[string]string{
+		"device/name":        "k10temp",
+		"device/temp1_input": "60125",
+	})
+	writeSysfsAttrs(t, filepath.Join(root, "class", "hwmon", "hwmon3"), map[string]string{"name": "empty"})
+	return root
+}
+
+func TestGetSensors(t *testing.T) {
+	gopsUtil := &GopsUtil{sysRoot: sensorsFixture(t)}
+
+	chips, err := gopsUtil.GetSensors()
+	require.NoError(t, err)
+	require.Len(t, chips, 3)
+
+	assert.Equal(t, "coretemp", chips[0].Name)
+	assert.Equal(t, "hwmon0", chips[0].Hwmon)
+	require.Len(t, chips[0].Readings, 1)
+	assert.Equal(t, "Package id 0", chips[0].Readings[0].Label)
+	assert.InDelta(t, 105.0, chips[0].Readings[0].Critical, 0.001)
+
+	assert.Equal(t, "k10temp", chips[1].Name)
+	require.Len(t, chips[1].Readings, 1)
+	assert.InDelta(t, 60.125, chips[1].Readings[0].Value, 0.001)
+
+	nct := chips[2]
+	assert.Equal(t, "nct6798", nct.Name)
+	keys := make([]string, 0, len(nct.Readings))
+	for _, reading := range nct.Readings {
+		keys = append(keys, reading.Key)
+	}
+	assert.Equal(t, []string{"temp2", "temp10", "fan1", "fan2", "in0", "power1", "curr1"}, keys)
+
+	temp := nct.Readings[0]
+	assert.Equal(t, "SYSTIN", temp.Label)
+	assert.Equal(t, "°C", temp.Unit)
+	assert.InDelta(t, 45.5, temp.Value, 0.001)
+	assert.InDelta(t, 80.0, temp.Max, 0.001)
+	assert.Equal(t, "temp10", nct.Readings[1].Label)
+
+	assert.Empty(t, nct.Readings[2].Alarms)
+	assert.Equal(t, []string{"alarm"}, nct.Readings[3].Alarms)
+	assert.InDelta(t, 300.0, nct.Readings[3].Min, 0.001)
+
+	vcore := nct.Readings[4]
+	assert.Equal(t, "Vcore", vcore.Label)
+	assert.Equal(t, "V", vcore.Unit)
+	assert.InDelta(t, 1.032, vcore.Value, 0.001)
+	assert.Equal(t, []string{"max"}, vcore.Alarms)
+
+	power := nct.Readings[5]
+	assert.InDelta(t, 40.0, power.Value, 0.001)
+	assert.InDelta(t, 95.0, power.Critical, 0.001)
+	assert.InDelta(t, 2.5, nct.Readings[6].Value, 0.001)
+}
+
+func TestGetSensorsWithoutHwmon(t *testing.T) {
+	chips, err := (&GopsUtil{sysRoot: t.TempDir()}).GetSensors()
+	require.NoError(t, err)
+	assert.Empty(t, chips)
+}
+
+func TestSensorDeviceLink(t *testing.T) {
+	root := sensorsFixture(t)
+	pciDev := filepath.Join(root, "devices", "pci0000:00", "0000:03:00.0")
+	require.NoError(t, os.MkdirAll(pciDev, 0o755))
+	require.NoError(t, os.Symlink(pciDev, filepath.Join(root, "class", "hwmon", "hwmon0", "device")))
+
+	chips, err := (&GopsUtil{sysRoot: root}).GetSensors()
+	require.NoError(t, err)
+	assert.Equal(t, "0000:03:00.0", chips[0].Device)
+}
@@ -0,0 +1,47 @@
+package gops
+
+import (
+	"github.com/AvengeMedia/dgop/models"
+)
+
+// MetaStream produces successive meta frames for a long-lived consumer,
+// carrying the rate cursors from one frame to the next so clients don't have to.
+type MetaStream struct {
+	getMeta func(modules []string, params MetaParams) (*models.MetaInfo, error)
+	modules []string
+	params  MetaParams
+}
+
+func (self *GopsUtil) NewMetaStream(modules []string, params MetaParams) *MetaStream {
+	return &MetaStream{
+		getMeta: self.GetMeta,
+		modules: modules,
+		params:  params,
+	}
+}
+
+// Next collects a frame and remembers its cursors for the following call
+func (self *MetaStream) Next() (*models.MetaInfo, error) {
+	meta, err := self.getMeta(self.modules, self.params)
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.CPU != nil && meta.CPU.Cursor != "" {
+		self.params.CPUCursor = meta.CPU.Cursor
+	}
+	if meta.ProcCursor != "" {
+		self.params.ProcCursor = meta.ProcCursor
+	}
+	if meta.NetRate != nil && meta.NetRate.Cursor != "" {
+		self.params.NetRateCursor = meta.NetRate.Cursor
+	}
+	if meta.DiskRate != nil && meta.DiskRate.Cursor != "" {
+		self.params.DiskRateCursor = meta.DiskRate.Cursor
+	}
+	if meta.Power != nil && meta.Power.Cursor != "" {
+		self.params.PowerCursor = meta.Power.Cursor
+	}
+
+	return meta, nil
+}
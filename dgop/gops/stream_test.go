@@ -0,0 +1,49 @@
+package gops
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AvengeMedia/dgop/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetaStreamCarriesCursors(t *testing.T) {
+	var seen []MetaParams
+	stream := (&GopsUtil{}).NewMetaStream([]string{"cpu", "processes", "net-rate", "disk-rate", "power"}, MetaParams{ProcLimit: 5})
+	stream.getMeta = func(modules []string, params MetaParams) (*models.MetaInfo, error) {
+		seen = append(seen, params)
+		frame := len(seen)
+		meta := &models.MetaInfo{
+			CPU:        &models.CPUInfo{Cursor: fmt.Sprintf("cpu-%d", frame)},
+			ProcCursor: fmt.Sprintf("proc-%d", frame),
+			NetRate:    &models.NetworkRateResponse{Cursor: fmt.Sprintf("net-%d", frame)},
+			DiskRate:   &models.DiskRateResponse{Cursor: fmt.Sprintf("disk-%d", frame)},
+			Power:      &models.PowerInfo{Cursor: fmt.Sprintf("power-%d", frame)},
+		}
+		if frame == 3 {
+			// A frame without cursors keeps the previous ones
+			meta = &models.MetaInfo{}
+		}
+		return meta, nil
+	}
+
+	for range 4 {
+		_, err := stream.Next()
+		require.NoError(t, err)
+	}
+
+	assert.Empty(t, seen[0].CPUCursor)
+	assert.Equal(t, 5, seen[0].ProcLimit)
+	// Each call gets the cursors of the last frame that had them
+	for call, frame := range map[int]int{1: 1, 2: 2, 3: 2} {
+		params := seen[call]
+		assert.Equal(t, fmt.Sprintf("cpu-%d", frame), params.CPUCursor)
+		assert.Equal(t, fmt.Sprintf("proc-%d", frame), params.ProcCursor)
+		assert.Equal(t, fmt.Sprintf("net-%d", frame), params.NetRateCursor)
+		assert.Equal(t, fmt.Sprintf("disk-%d", frame), params.DiskRateCursor)
+		assert.Equal(t, fmt.Sprintf("power-%d", frame), params.PowerCursor)
+		assert.Equal(t, 5, params.ProcLimit)
+	}
+}
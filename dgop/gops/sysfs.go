@@ -0,0 +1,34 @@
+package gops
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// sysPath joins elem onto the sysfs root
+func (self *GopsUtil) sysPath(elem ...string) string {
+	root := self.sysRoot
+	if root == "" {
+		root = "/sys"
+	}
+	return filepath.Join(append([]string{root}, elem...)...)
+}
+
+// readSysfsString returns the trimmed attribute, or "" when it can't be read
+func readSysfsString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func readSysfsInt(path string) (int64, bool) {
+	v, err := strconv.ParseInt(readSysfsString(path), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
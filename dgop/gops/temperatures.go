@@ -0,0 +1,129 @@
+package gops
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/AvengeMedia/dgop/models"
+)
+
+// GetTemperatures returns temperature sensors grouped by chip. Systems
+// without hwmon temperatures fall back to /sys/class/thermal zones.
+func (self *GopsUtil) GetTemperatures() ([]*models.TemperatureChip, error) {
+	sensorChips, err := self.GetSensors()
+	if err != nil {
+		return nil, err
+	}
+
+	chips := make([]*models.TemperatureChip, 0, len(sensorChips))
+	for _, sensorChip := range sensorChips {
+		chip := &models.TemperatureChip{
+			Name:    sensorChip.Name,
+			Device:  sensorChip.Device,
+			Sensors: make([]*models.TemperatureSensor, 0),
+		}
+		for _, reading := range sensorChip.Readings {
+			if reading.Type != "temp" {
+				continue
+			}
+			label := ""
+			if reading.Label != reading.Key {
+				label = reading.Label
+			}
+			chip.Sensors = append(chip.Sensors, &models.TemperatureSensor{
+				Name:        temperatureSensorName(chip.Name, label),
+				Chip:        chip.Name,
+				Label:       reading.Label,
+				Temperature: reading.Value,
+				High:        reading.Max,
+				Critical:    reading.Critical,
+				Alarms:      reading.Alarms,
+			})
+		}
+		if len(chip.Sensors) > 0 {
+			chips = append(chips, chip)
+		}
+	}
+
+	if len(chips) == 0 {
+		return self.readThermalZones()
+	}
+	return chips, nil
+}
+
+// GetSystemTemperatures returns every temperature sensor in one list
+func (self *GopsUtil) GetSystemTemperatures() ([]models.TemperatureSensor, error) {
+	chips, err := self.GetTemperatures()
+	if err != nil {
+		return nil, err
+	}
+
+	var sensors []models.TemperatureSensor
+	for _, chip := range chips {
+		for _, sensor := range chip.Sensors {
+			sensors = append(sensors, *sensor)
+		}
+	}
+
+	return sensors, nil
+}
+
+// readThermalZones reads ACPI and SoC thermal zones, each as its own chip
+// with the hot and critical trip points as thresholds
+func (self *GopsUtil) readThermalZones() ([]*models.TemperatureChip, error) {
+	zones, err := filepath.Glob(self.sysPath("class", "thermal", "thermal_zone*"))
+	if err != nil {
+		return nil, err
+	}
+
+	chips := make([]*models.TemperatureChip, 0, len(zones))
+	for _, zone := range zones {
+		zoneType := readSysfsString(filepath.Join(zone, "type"))
+		temp, ok := readSysfsInt(filepath.Join(zone, "temp"))
+		if zoneType == "" || !ok {
+			continue
+		}
+
+		sensor := &models.TemperatureSensor{
+			Name:        zoneType,
+			Chip:        zoneType,
+			Label:       zoneType,
+			Temperature: float64(temp) / 1000,
+		}
+
+		trips, _ := filepath.Glob(filepath.Join(zone, "trip_point_*_type"))
+		for _, trip := range trips {
+			tripTemp, ok := readSysfsInt(strings.TrimSuffix(trip, "_type") + "_temp")
+			if !ok {
+				continue
+			}
+			switch readSysfsString(trip) {
+			case "hot":
+				sensor.High = float64(tripTemp) / 1000
+			case "critical":
+				sensor.Critical = float64(tripTemp) / 1000
+			}
+		}
+
+		chips = append(chips, &models.TemperatureChip{
+			Name:    zoneType,
+			Device:  filepath.Base(zone),
+			Sensors: []*models.TemperatureSensor{sensor},
+		})
+	}
+
+	sort.SliceStable(chips, func(i, j int) bool {
+		return chips[i].Name < chips[j].Name
+	})
+	return chips, nil
+}
+
+// temperatureSensorName keeps the chip_label names gopsutil used to give,
+// e.g. "Core 0" on coretemp is coretemp_core_0
+func temperatureSensorName(chip, label string) string {
+	if label == "" {
+		return chip
+	}
+	return chip + "_" + strings.Join(strings.Fields(strings.ToLower(label)), "_")
+}
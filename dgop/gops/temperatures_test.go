@@ -0,0 +1,60 @@
+package gops
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTemperatures(t *testing.T) {
+	gopsUtil := &GopsUtil{sysRoot: sensorsFixture(t)}
+
+	chips, err := gopsUtil.GetTemperatures()
+	require.NoError(t, err)
+	require.Len(t, chips, 3)
+
+	coretemp := chips[0].Sensors[0]
+	assert.Equal(t, "coretemp_package_id_0", coretemp.Name)
+	assert.Equal(t, "coretemp", coretemp.Chip)
+	assert.Equal(t, "Package id 0", coretemp.Label)
+	assert.InDelta(t, 51.0, coretemp.Temperature, 0.001)
+	assert.InDelta(t, 105.0, coretemp.Critical, 0.001)
+
+	// Fans, voltages and power readings are left out
+	nct := chips[2]
+	assert.Equal(t, "nct6798", nct.Name)
+	require.Len(t, nct.Sensors, 2)
+	assert.Equal(t, "nct6798_systin", nct.Sensors[0].Name)
+	assert.InDelta(t, 80.0, nct.Sensors[0].High, 0.001)
+	assert.Equal(t, "nct6798", nct.Sensors[1].Name)
+	assert.Equal(t, "temp10", nct.Sensors[1].Label)
+
+	flat, err := gopsUtil.GetSystemTemperatures()
+	require.NoError(t, err)
+	assert.Len(t, flat, 4)
+}
+
+func TestGetTemperaturesFromThermalZones(t *testing.T) {
+	root := t.TempDir()
+	zone := filepath.Join(root, "class", "thermal", "thermal_zone0")
+	writeFixture(t, filepath.Join(zone, "type"), "x86_pkg_temp\n")
+	writeFixture(t, filepath.Join(zone, "temp"), "47000\n")
+	writeFixture(t, filepath.Join(zone, "trip_point_0_type"), "passive\n")
+	writeFixture(t, filepath.Join(zone, "trip_point_0_temp"), "90000\n")
+	writeFixture(t, filepath.Join(zone, "trip_point_1_type"), "critical\n")
+	writeFixture(t, filepath.Join(zone, "trip_point_1_temp"), "105000\n")
+	// A fan-only hwmon chip doesn't count as having temperatures
+	writeSysfsAttrs(t, filepath.Join(root, "class", "hwmon", "hwmon0"), map[string]string{"name": "dell_smm", "fan1_input": "2000"})
+
+	chips, err := (&GopsUtil{sysRoot: root}).GetTemperatures()
+	require.NoError(t, err)
+	require.Len(t, chips, 1)
+	assert.Equal(t, "x86_pkg_temp", chips[0].Name)
+	assert.Equal(t, "thermal_zone0", chips[0].Device)
+	require.Len(t, chips[0].Sensors, 1)
+	assert.InDelta(t, 47.0, chips[0].Sensors[0].Temperature, 0.001)
+	assert.Zero(t, chips[0].Sensors[0].High)
+	assert.InDelta(t, 105.0, chips[0].Sensors[0].Critical, 0.001)
+}
@@ -0,0 +1,40 @@
+package models
+
+// BlockDevice is a /sys/block entry. Sizes are in bytes.
+type BlockDevice struct {
+	Name       string            `json:"name"`
+	Type       string            `json:"type"` // disk, dm, md, loop, zram or rom
+	Model      string            `json:"model,omitempty"`
+	Vendor     string            `json:"vendor,omitempty"`
+	Serial     string            `json:"serial,omitempty"`
+	Size       uint64            `json:"size"`
+	Rotational bool              `json:"rotational"`
+	Removable  bool              `json:"removable"`
+	ReadOnly   bool              `json:"readOnly"`
+	Scheduler  string            `json:"scheduler,omitempty"`
+	QueueDepth int               `json:"queueDepth,omitempty"`
+	DMName     string            `json:"dmName,omitempty"` // Mapper name, e.g. cryptroot or vg-home
+	DMType     string            `json:"dmType,omitempty"` // Target from the dm uuid: crypt, lvm, mpath, ...
+	Partitions []*BlockPartition `json:"partitions"`
+	Holders    []string          `json:"holders"` // Devices stacked on top, e.g. dm-0
+	Slaves     []string          `json:"slaves"`  // Devices underneath a dm or md device
+	SMART      *SMARTInfo        `json:"smart,omitempty"`
+}
+
+type BlockPartition struct {
+	Name     string   `json:"name"`
+	Number   int      `json:"number"`
+	Start    uint64   `json:"start"`
+	Size     uint64   `json:"size"`
+	ReadOnly bool     `json:"readOnly"`
+	Holders  []string `json:"holders"`
+}
+
+// SMARTInfo is the health summary smartctl reports for a disk
+type SMARTInfo struct {
+	Passed         bool    `json:"passed"`
+	Temperature    float64 `json:"temperature"` // °C
+	PowerOnHours   uint64  `json:"powerOnHours"`
+	PowerCycles    uint64  `json:"powerCycles"`
+	PercentageUsed int     `json:"percentageUsed,omitempty"` // NVMe endurance used
+}
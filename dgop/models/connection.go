@@ -0,0 +1,17 @@
+package models
+
+// ConnectionInfo is one socket from /proc/net. For unix sockets LocalAddr is
+// the socket path, which is empty for unnamed sockets.
+type ConnectionInfo struct {
+	Protocol   string `json:"protocol"` // tcp, tcp6, udp, udp6 or unix
+	LocalAddr  string `json:"localAddr"`
+	LocalPort  uint16 `json:"localPort"`
+	RemoteAddr string `json:"remoteAddr"`
+	RemotePort uint16 `json:"remotePort"`
+	State      string `json:"state"`
+	Listening  bool   `json:"listening"`
+	Inode      uint64 `json:"inode"`
+	UID        uint32 `json:"uid"`           // Not reported for unix sockets
+	PID        int32  `json:"pid,omitempty"` // 0 when the owner can't be read
+	Command    string `json:"command,omitempty"`
+}
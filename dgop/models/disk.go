@@ -6,16 +6,32 @@ type DiskInfo struct {
 	Write uint64 `json:"write"`
 }
 
+// DiskMountInfo keeps the formatted Size, Used, Avail and Percent for
+// display, the numeric fields hold the same values in bytes and percent
 type DiskMountInfo struct {
-	Device  string `json:"device"`
-	Mount   string `json:"mount"`
-	FSType  string `json:"fstype"`
-	Size    string `json:"size"`
-	Used    string `json:"used"`
-	Avail   string `json:"avail"`
-	Percent string `json:"percent"`
+	Device            string   `json:"device"`
+	Mount             string   `json:"mount"`
+	FSType            string   `json:"fstype"`
+	Size              string   `json:"size"`
+	Used              string   `json:"used"`
+	Avail             string   `json:"avail"`
+	Percent           string   `json:"percent"`
+	SizeBytes         uint64   `json:"sizeBytes"`
+	UsedBytes         uint64   `json:"usedBytes"`
+	AvailBytes        uint64   `json:"availBytes"` // Available to unprivileged users
+	UsedPercent       float64  `json:"usedPercent"`
+	InodesTotal       uint64   `json:"inodesTotal"`
+	InodesUsed        uint64   `json:"inodesUsed"`
+	InodesFree        uint64   `json:"inodesFree"`
+	InodesUsedPercent float64  `json:"inodesUsedPercent"`
+	ReadOnly          bool     `json:"readOnly"`
+	Options           []string `json:"options"`
 }
 
+// DiskRateInfo holds byte rates plus the iostat -x view of the interval
+// since the cursor. Await is in milliseconds per completed request, Util is
+// the percentage of time the device was busy and QueueSize the average
+// number of requests in flight.
 type DiskRateInfo struct {
 	Device     string  `json:"device"`
 	ReadRate   float64 `json:"readrate"`
@@ -24,6 +40,13 @@ type DiskRateInfo struct {
 	WriteTotal uint64  `json:"writetotal"`
 	ReadCount  uint64  `json:"readcount"`
 	WriteCount uint64  `json:"writecount"`
+	ReadIOPS   float64 `json:"readiops"`
+	WriteIOPS  float64 `json:"writeiops"`
+	ReadAwait  float64 `json:"readawait"`
+	WriteAwait float64 `json:"writeawait"`
+	Util       float64 `json:"util"`
+	QueueSize  float64 `json:"queuesize"`
+	InFlight   uint64  `json:"inflight"`
 }
 
 type DiskRateResponse struct {
@@ -27,7 +27,10 @@ type GPU struct {
 	DisplayName string  `json:"displayName"`
 	FullName    string  `json:"fullName"`
 	PciId       string  `json:"pciId"`
-	RawLine     string  `json:"rawLine"`
+	BDF         string  `json:"bdf"`                   // 0000:03:00.0
+	SubsystemId string  `json:"subsystemId,omitempty"` // Board vendor and model, 1da2:e471
+	Subsystem   string  `json:"subsystem,omitempty"`
+	RawLine     string  `json:"rawLine"` // Formatted like a line of lspci -nnD
 	Temperature float64 `json:"temperature"`
 	Hwmon       string  `json:"hwmon"`
 }
@@ -45,3 +48,27 @@ type GPUTempInfo struct {
 type GPUTempsInfo struct {
 	GPUTemps []GPUTempInfo `json:"gputemps"`
 }
+
+// GPUUsage is the load of one card as its kernel driver reports it in sysfs.
+// Values the driver doesn't expose are left at zero.
+type GPUUsage struct {
+	BDF               string  `json:"bdf"` // 0000:03:00.0
+	PciId             string  `json:"pciId"`
+	Driver            string  `json:"driver"`
+	Card              string  `json:"card,omitempty"`  // card1
+	Hwmon             string  `json:"hwmon,omitempty"` // hwmon3
+	Utilization       float64 `json:"utilization"`     // percent
+	MemoryUtilization float64 `json:"memoryUtilization"`
+	VRAMUsed          uint64  `json:"vramUsed"` // bytes
+	VRAMTotal         uint64  `json:"vramTotal"`
+	CoreClock         int     `json:"coreClock"` // MHz
+	CoreClockMax      int     `json:"coreClockMax"`
+	MemoryClock       int     `json:"memoryClock"`
+	MemoryClockMax    int     `json:"memoryClockMax"`
+	Power             float64 `json:"power"` // W
+	PowerCap          float64 `json:"powerCap"`
+}
+
+type GPUUsageInfo struct {
+	GPUs []*GPUUsage `json:"gpus"`
+}
@@ -1,12 +1,68 @@
 package models
 
+// MemoryInfo sizes are in KiB
 type MemoryInfo struct {
-	Total     uint64 `json:"total"`
-	Free      uint64 `json:"free"`
-	Available uint64 `json:"available"`
-	Buffers   uint64 `json:"buffers"`
-	Cached    uint64 `json:"cached"`
-	Shared    uint64 `json:"shared"`
-	SwapTotal uint64 `json:"swaptotal"`
-	SwapFree  uint64 `json:"swapfree"`
+	Total     uint64          `json:"total"`
+	Free      uint64          `json:"free"`
+	Available uint64          `json:"available"`
+	Buffers   uint64          `json:"buffers"`
+	Cached    uint64          `json:"cached"`
+	Shared    uint64          `json:"shared"`
+	SwapTotal uint64          `json:"swaptotal"`
+	SwapFree  uint64          `json:"swapfree"`
+	Extended  *MemoryExtended `json:"extended,omitempty"`
+	Zram      []*ZramDevice   `json:"zram,omitempty"`
+	Zswap     *ZswapInfo      `json:"zswap,omitempty"`
+}
+
+// MemoryExtended is the rest of /proc/meminfo, in KiB except for the
+// HugePages counts
+type MemoryExtended struct {
+	Active         uint64 `json:"active"`
+	Inactive       uint64 `json:"inactive"`
+	SwapCached     uint64 `json:"swapCached"`
+	Dirty          uint64 `json:"dirty"`
+	Writeback      uint64 `json:"writeback"`
+	AnonPages      uint64 `json:"anonPages"`
+	Mapped         uint64 `json:"mapped"`
+	Shmem          uint64 `json:"shmem"`
+	Slab           uint64 `json:"slab"`
+	SReclaimable   uint64 `json:"sReclaimable"`
+	SUnreclaim     uint64 `json:"sUnreclaim"`
+	KernelStack    uint64 `json:"kernelStack"`
+	PageTables     uint64 `json:"pageTables"`
+	CommitLimit    uint64 `json:"commitLimit"`
+	CommittedAS    uint64 `json:"committedAS"`
+	AnonHugePages  uint64 `json:"anonHugePages"`
+	HugePagesTotal uint64 `json:"hugePagesTotal"`
+	HugePagesFree  uint64 `json:"hugePagesFree"`
+	HugePagesRsvd  uint64 `json:"hugePagesRsvd"`
+	HugePagesSurp  uint64 `json:"hugePagesSurp"`
+	HugePageSize   uint64 `json:"hugePageSize"`
+}
+
+// ZramDevice is an initialized /sys/block/zram* device, sizes in KiB
+type ZramDevice struct {
+	Name             string  `json:"name"`
+	Algorithm        string  `json:"algorithm"`
+	DiskSize         uint64  `json:"diskSize"`
+	OrigDataSize     uint64  `json:"origDataSize"`  // Uncompressed data stored
+	ComprDataSize    uint64  `json:"comprDataSize"` // The same data compressed
+	MemUsedTotal     uint64  `json:"memUsedTotal"`  // Including allocator overhead
+	MemLimit         uint64  `json:"memLimit"`
+	MemUsedMax       uint64  `json:"memUsedMax"`
+	SamePages        uint64  `json:"samePages"`
+	CompressionRatio float64 `json:"compressionRatio"` // origDataSize / comprDataSize
+}
+
+// ZswapInfo is the zswap configuration and pool, sizes in KiB. Pool sizes
+// come from /proc/meminfo and need Linux 5.19 or newer.
+type ZswapInfo struct {
+	Enabled          bool    `json:"enabled"`
+	Compressor       string  `json:"compressor"`
+	Zpool            string  `json:"zpool,omitempty"`
+	MaxPoolPercent   int     `json:"maxPoolPercent"`
+	PoolSize         uint64  `json:"poolSize"`   // Zswap in meminfo
+	StoredSize       uint64  `json:"storedSize"` // Zswapped in meminfo
+	CompressionRatio float64 `json:"compressionRatio"`
 }
@@ -18,17 +18,24 @@ type SystemInfo struct {
 }
 
 type MetaInfo struct {
-	CPU        *CPUInfo             `json:"cpu,omitempty"`
-	Memory     *MemoryInfo          `json:"memory,omitempty"`
-	Network    []*NetworkInfo       `json:"network,omitempty"`
-	NetRate    *NetworkRateResponse `json:"netrate,omitempty"`
-	Disk       []*DiskInfo          `json:"disk,omitempty"`
-	DiskRate   *DiskRateResponse    `json:"diskrate,omitempty"`
-	DiskMounts []*DiskMountInfo     `json:"diskmounts,omitempty"`
-	Processes  []*ProcessInfo       `json:"processes,omitempty"`
-	System     *SystemInfo          `json:"system,omitempty"`
-	Hardware   *SystemHardware      `json:"hardware,omitempty"`
-	GPU        *GPUInfo             `json:"gpu,omitempty"`
+	CPU          *CPUInfo             `json:"cpu,omitempty"`
+	Memory       *MemoryInfo          `json:"memory,omitempty"`
+	Network      []*NetworkInfo       `json:"network,omitempty"`
+	NetRate      *NetworkRateResponse `json:"netrate,omitempty"`
+	Disk         []*DiskInfo          `json:"disk,omitempty"`
+	DiskRate     *DiskRateResponse    `json:"diskrate,omitempty"`
+	DiskMounts   []*DiskMountInfo     `json:"diskmounts,omitempty"`
+	BlockDevices []*BlockDevice       `json:"blockdevices,omitempty"`
+	Processes    []*ProcessInfo       `json:"processes,omitempty"`
+	ProcCursor   string               `json:"proccursor,omitempty"`
+	System       *SystemInfo          `json:"system,omitempty"`
+	Hardware     *SystemHardware      `json:"hardware,omitempty"`
+	GPU          *GPUInfo             `json:"gpu,omitempty"`
+	GPUUsage     *GPUUsageInfo        `json:"gpuusage,omitempty"`
+	Connections  []*ConnectionInfo    `json:"connections,omitempty"`
+	Power        *PowerInfo           `json:"power,omitempty"`
+	Sensors      []*SensorChip        `json:"sensors,omitempty"`
+	Temperatures []*TemperatureChip   `json:"temperatures,omitempty"`
 }
 
 type ModulesInfo struct {
@@ -1,17 +1,48 @@
 package models
 
 type NetworkInfo struct {
-	Name string `json:"name"`
-	Rx   uint64 `json:"rx"`
-	Tx   uint64 `json:"tx"`
+	Name      string            `json:"name"`
+	Rx        uint64            `json:"rx"`
+	Tx        uint64            `json:"tx"`
+	Type      string            `json:"type"` // physical, wireless, loopback, bridge, vpn or virtual
+	MAC       string            `json:"mac,omitempty"`
+	MTU       int               `json:"mtu"`
+	OperState string            `json:"operstate"` // up, down, dormant, unknown, ...
+	Carrier   bool              `json:"carrier"`
+	Speed     int               `json:"speed,omitempty"`  // Mbit/s, zero when the link doesn't report it
+	Duplex    string            `json:"duplex,omitempty"` // full or half
+	Driver    string            `json:"driver,omitempty"`
+	Addresses []*NetworkAddress `json:"addresses"`
+	Wireless  *WirelessInfo     `json:"wireless,omitempty"`
 }
 
+type NetworkAddress struct {
+	Address string `json:"address"`
+	Prefix  int    `json:"prefix"`
+	Family  string `json:"family"` // ipv4 or ipv6
+}
+
+// WirelessInfo is the interface's line in /proc/net/wireless
+type WirelessInfo struct {
+	LinkQuality float64 `json:"linkquality"` // Driver scale, usually out of 70
+	SignalLevel float64 `json:"signallevel"` // dBm
+	NoiseLevel  float64 `json:"noiselevel"`  // dBm
+}
+
+// NetworkRateInfo holds per second rates since the cursor. Errors and
+// drops are packets, see /proc/net/dev.
 type NetworkRateInfo struct {
-	Interface string  `json:"interface"`
-	RxRate    float64 `json:"rxrate"`
-	TxRate    float64 `json:"txrate"`
-	RxTotal   uint64  `json:"rxtotal"`
-	TxTotal   uint64  `json:"txtotal"`
+	Interface    string  `json:"interface"`
+	RxRate       float64 `json:"rxrate"`
+	TxRate       float64 `json:"txrate"`
+	RxTotal      uint64  `json:"rxtotal"`
+	TxTotal      uint64  `json:"txtotal"`
+	RxPacketRate float64 `json:"rxpacketrate"`
+	TxPacketRate float64 `json:"txpacketrate"`
+	RxErrorRate  float64 `json:"rxerrorrate"`
+	TxErrorRate  float64 `json:"txerrorrate"`
+	RxDropRate   float64 `json:"rxdroprate"`
+	TxDropRate   float64 `json:"txdroprate"`
 }
 
 type NetworkRateResponse struct {
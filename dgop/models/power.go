@@ -0,0 +1,37 @@
+package models
+
+// BatteryInfo is a battery from /sys/class/power_supply. Energy is in Wh and
+// power in W, batteries that only report charge are converted using their
+// voltage. Values the battery doesn't report are left at zero.
+type BatteryInfo struct {
+	Name             string  `json:"name"`
+	Scope            string  `json:"scope,omitempty"` // Device for peripherals such as mice
+	Manufacturer     string  `json:"manufacturer,omitempty"`
+	Model            string  `json:"model,omitempty"`
+	Technology       string  `json:"technology,omitempty"`
+	Status           string  `json:"status"` // Charging, Discharging, Full, Not charging, Unknown
+	Capacity         int     `json:"capacity"`
+	EnergyNow        float64 `json:"energyNow"`
+	EnergyFull       float64 `json:"energyFull"`
+	EnergyFullDesign float64 `json:"energyFullDesign"`
+	PowerNow         float64 `json:"powerNow"`
+	Voltage          float64 `json:"voltage"`
+	CycleCount       int     `json:"cycleCount"`
+	Health           string  `json:"health,omitempty"`
+	HealthPercent    float64 `json:"healthPercent"` // energyFull of energyFullDesign
+	TimeToEmpty      int64   `json:"timeToEmpty"`   // seconds, 0 when not discharging or unknown
+	TimeToFull       int64   `json:"timeToFull"`    // seconds, 0 when not charging or unknown
+}
+
+type PowerAdapterInfo struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"` // Mains, USB, ...
+	Online bool   `json:"online"`
+}
+
+type PowerInfo struct {
+	Batteries []*BatteryInfo      `json:"batteries"`
+	Adapters  []*PowerAdapterInfo `json:"adapters"`
+	OnBattery bool                `json:"onBattery"`
+	Cursor    string              `json:"cursor,omitempty"`
+}
@@ -15,15 +15,86 @@ type ProcessInfo struct {
 	Username          string  `json:"username"`
 	Command           string  `json:"command"`
 	FullCommand       string  `json:"fullCommand"`
+	IOReadBytes       uint64  `json:"ioReadBytes"`
+	IOWriteBytes      uint64  `json:"ioWriteBytes"`
+	IOReadRate        float64 `json:"ioReadRate"`  // bytes per second, needs a cursor
+	IOWriteRate       float64 `json:"ioWriteRate"` // bytes per second, needs a cursor
 }
 
 type ProcessCursorData struct {
-	PID       int32   `json:"pid"`
-	Ticks     float64 `json:"ticks"`
-	Timestamp int64   `json:"timestamp"`
+	PID          int32   `json:"pid"`
+	Ticks        float64 `json:"ticks"`
+	Timestamp    int64   `json:"timestamp"`
+	IOReadBytes  uint64  `json:"ioRead,omitempty"`
+	IOWriteBytes uint64  `json:"ioWrite,omitempty"`
 }
 
 type ProcessListResponse struct {
 	Processes []*ProcessInfo `json:"processes"`
 	Cursor    string         `json:"cursor,omitempty"`
 }
+
+// ProcessTreeNode is a process with its children nested under it. The
+// subtree fields add up the process and all of its descendants.
+type ProcessTreeNode struct {
+	*ProcessInfo
+	SubtreeCPU           float64            `json:"subtreeCpu"`
+	SubtreeMemoryKB      uint64             `json:"subtreeMemoryKB"`
+	SubtreeMemoryPercent float32            `json:"subtreeMemoryPercent"`
+	Children             []*ProcessTreeNode `json:"children,omitempty"`
+}
+
+type ProcessTreeResponse struct {
+	Processes []*ProcessTreeNode `json:"processes"`
+	Cursor    string             `json:"cursor,omitempty"`
+}
+
+// ProcessDetail is everything /proc knows about a single process. Fields the
+// caller may not read (another user's fds, cwd, io...) are left empty.
+type ProcessDetail struct {
+	PID               int32              `json:"pid"`
+	PPID              int32              `json:"ppid"`
+	Username          string             `json:"username"`
+	Command           string             `json:"command"`
+	FullCommand       string             `json:"fullCommand"`
+	State             string             `json:"state"`
+	StateDescription  string             `json:"stateDescription"`
+	StartTime         int64              `json:"startTime"` // unix milliseconds
+	Nice              int                `json:"nice"`
+	Priority          int                `json:"priority"`
+	Threads           int                `json:"threads"`
+	FDCount           int                `json:"fdCount"`
+	FDs               []ProcessFD        `json:"fds,omitempty"`
+	Cwd               string             `json:"cwd,omitempty"`
+	Exe               string             `json:"exe,omitempty"`
+	Environ           []string           `json:"environ,omitempty"`
+	Rlimits           []ProcessRlimit    `json:"rlimits,omitempty"`
+	Cgroup            string             `json:"cgroup,omitempty"`
+	CgroupControllers map[string]string  `json:"cgroupControllers,omitempty"`
+	Namespaces        map[string]uint64  `json:"namespaces,omitempty"`
+	IO                *ProcessIOCounters `json:"io,omitempty"`
+}
+
+type ProcessFD struct {
+	FD     int    `json:"fd"`
+	Target string `json:"target"`
+}
+
+// ProcessRlimit values are -1 when unlimited
+type ProcessRlimit struct {
+	Resource string `json:"resource"`
+	Soft     int64  `json:"soft"`
+	Hard     int64  `json:"hard"`
+	Unit     string `json:"unit,omitempty"`
+}
+
+// ProcessIOCounters mirrors /proc/pid/io
+type ProcessIOCounters struct {
+	ReadChars           uint64 `json:"readChars"`
+	WriteChars          uint64 `json:"writeChars"`
+	ReadSyscalls        uint64 `json:"readSyscalls"`
+	WriteSyscalls       uint64 `json:"writeSyscalls"`
+	ReadBytes           uint64 `json:"readBytes"`
+	WriteBytes          uint64 `json:"writeBytes"`
+	CancelledWriteBytes uint64 `json:"cancelledWriteBytes"`
+}
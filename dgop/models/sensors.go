@@ -0,0 +1,26 @@
+package models
+
+// SensorChip is one /sys/class/hwmon device and everything it measures
+type SensorChip struct {
+	Name     string           `json:"name"`             // Driver name, e.g. coretemp, nct6798, amdgpu
+	Hwmon    string           `json:"hwmon"`            // hwmon0
+	Device   string           `json:"device,omitempty"` // Parent device, e.g. a PCI address
+	Readings []*SensorReading `json:"readings"`
+}
+
+// SensorReading is one hwmon channel such as temp1 or fan2. Values are in
+// °C, RPM, V, W or A depending on Type. Thresholds the chip doesn't report
+// are left at zero.
+type SensorReading struct {
+	Type      string   `json:"type"` // temp, fan, in, power or curr
+	Key       string   `json:"key"`  // temp1
+	Label     string   `json:"label"`
+	Value     float64  `json:"value"`
+	Unit      string   `json:"unit"`
+	Min       float64  `json:"min,omitempty"`
+	Max       float64  `json:"max,omitempty"`
+	Critical  float64  `json:"crit,omitempty"`
+	LowCrit   float64  `json:"lcrit,omitempty"`
+	Emergency float64  `json:"emergency,omitempty"`
+	Alarms    []string `json:"alarms,omitempty"` // Raised alarms: alarm, min, max, crit, lcrit, emergency or fault
+}
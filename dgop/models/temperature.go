@@ -1,8 +1,19 @@
 package models
 
 type TemperatureSensor struct {
-	Name        string  `json:"name"`
-	Temperature float64 `json:"temperature"`
-	High        float64 `json:"high"`
-	Critical    float64 `json:"critical"`
+	Name        string   `json:"name"`  // Chip and label, e.g. coretemp_package_id_0
+	Chip        string   `json:"chip"`  // coretemp
+	Label       string   `json:"label"` // Package id 0, or temp1 when the chip has no label
+	Temperature float64  `json:"temperature"`
+	High        float64  `json:"high"`
+	Critical    float64  `json:"critical"`
+	Alarms      []string `json:"alarms,omitempty"`
+}
+
+// TemperatureChip groups the temperature sensors of one hwmon chip or
+// thermal zone
+type TemperatureChip struct {
+	Name    string               `json:"name"`
+	Device  string               `json:"device,omitempty"`
+	Sensors []*TemperatureSensor `json:"sensors"`
 }